@@ -0,0 +1,42 @@
+// Comando recalcular-scouts reprocessa todos os jogos encerrados e recalcula as
+// estatísticas (scouts), os ratings e o histórico de ratings de todos os jogadores.
+//
+// Por padrão apenas relata as diferenças encontradas; use -aplicar para gravá-las.
+//
+//	go run ./cmd/recalcular-scouts            # simulação
+//	go run ./cmd/recalcular-scouts -aplicar   # grava as correções
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"competitions/config"
+	"competitions/repository"
+)
+
+func main() {
+	aplicar := flag.Bool("aplicar", false, "grava as estatísticas recalculadas (sem esta opção, apenas relata as diferenças)")
+	flag.Parse()
+
+	config.ConnectDatabase()
+	defer config.DB.Close()
+
+	relatorio, err := repository.NewScoutRepository(config.DB).Recalcular(context.Background(), !*aplicar)
+	if err != nil {
+		log.Fatalf("Erro ao recalcular scouts: %v", err)
+	}
+
+	saida := json.NewEncoder(os.Stdout)
+	saida.SetIndent("", "  ")
+	if err := saida.Encode(relatorio); err != nil {
+		log.Fatalf("Erro ao escrever relatório: %v", err)
+	}
+	log.Printf("%d jogos processados, %d scouts e %d ratings divergentes.", relatorio.JogosProcessados, len(relatorio.Scouts), len(relatorio.Ratings))
+	if relatorio.Simulacao {
+		log.Println("Simulação: nada foi gravado. Use -aplicar para corrigir.")
+	}
+}
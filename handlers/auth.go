@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"competitions/middleware"
+	"competitions/models"
 	"competitions/repository"
+	"competitions/validation"
+	"context"
 	"errors"
 	"log"
+	"net/http"
 
 	jwt "github.com/appleboy/gin-jwt/v2"
 	"github.com/gin-gonic/gin"
@@ -58,5 +63,111 @@ func (h *AuthHandler) Login(c *gin.Context) (any, error) {
 		return nil, jwt.ErrFailedAuthentication
 	}
 
+	// A versão dos tokens é gravada no token emitido; contas desativadas não fazem login.
+	versao, ativo, err := h.UserRepo.FindVersaoToken(c.Request.Context(), user.ID)
+	if err != nil {
+		log.Printf("Erro ao buscar versão dos tokens do usuário %d: %v", user.ID, err)
+		return nil, jwt.ErrFailedAuthentication
+	}
+	if !ativo {
+		return nil, jwt.ErrFailedAuthentication
+	}
+	user.VersaoToken = versao
+
 	return user, nil
 }
+
+// TokenVigente informa se um token com a versão informada ainda é válido para o usuário: a
+// conta precisa existir, estar ativa e não ter tido os tokens revogados depois da emissão.
+func (h *AuthHandler) TokenVigente(ctx context.Context, userID uint, versao int) bool {
+	atual, ativo, err := h.UserRepo.FindVersaoToken(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Erro ao verificar a versão dos tokens do usuário %d: %v", userID, err)
+		}
+		return false
+	}
+	return ativo && atual == versao
+}
+
+// Logout godoc
+//
+//	@Summary		Encerra as sessões do usuário autenticado
+//	@Description	Revoga todos os tokens já emitidos para o usuário autenticado, inclusive o usado nesta requisição, em todos os dispositivos. Um novo login é necessário para continuar usando a API.
+//	@Tags			Autenticação
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	SuccessResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID := uint(middleware.UsuarioID(c))
+	if _, err := h.UserRepo.RevogarTokens(c.Request.Context(), userID); err != nil {
+		log.Printf("Erro ao revogar os tokens do usuário %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao encerrar a sessão."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sessão encerrada com sucesso."})
+}
+
+// Signup godoc
+//
+//	@Summary		Cadastra um novo jogador
+//	@Description	Cadastro público, sem autenticação. Cria uma conta ativa do tipo 'jogador' e o jogador correspondente, com o sexo e o tipo de mão informados. Tipos com privilégios não podem ser escolhidos no cadastro. E-mail, username e CPF já cadastrados retornam 409 com o campo em conflito.
+//	@Tags			Autenticação
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		models.SignupInput	true	"Dados do cadastro"
+//	@Success		201		{object}	models.Usuario
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/auth/signup [post]
+func (h *AuthHandler) Signup(c *gin.Context) {
+	var input models.SignupInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("Erro ao gerar hash da senha: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro interno."})
+		return
+	}
+
+	usuario := models.Usuario{
+		Nome:           input.Nome,
+		Username:       input.Username,
+		CPF:            input.CPF,
+		DataNascimento: input.DataNascimento,
+		Email:          input.Email,
+		Password:       string(hashedPassword),
+		Telefone:       input.Telefone,
+		Instagram:      input.Instagram,
+		Ativo:          true,
+	}
+
+	if err := h.UserRepo.CreateJogador(c.Request.Context(), &usuario, input.Sexo, input.TipoMao); err != nil {
+		if respostaConflitoUsuario(c, err) {
+			return
+		}
+		log.Printf("Erro no cadastro de jogador: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao realizar o cadastro."})
+		return
+	}
+
+	usuario.Password = ""
+	c.JSON(http.StatusCreated, usuario)
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"competitions/models"
+	"competitions/repository"
+	"competitions/validation"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ChaveamentoHandler encapsula a lógica para as rotas de chaveamentos (eliminatória simples).
+type ChaveamentoHandler struct {
+	repo repository.ChaveamentoRepository
+}
+
+// NewChaveamentoHandler cria uma nova instância de ChaveamentoHandler com o repositório fornecido.
+func NewChaveamentoHandler(repo repository.ChaveamentoRepository) *ChaveamentoHandler {
+	return &ChaveamentoHandler{repo: repo}
+}
+
+// respostaErroChaveamento traduz os erros de geração de chaveamentos em respostas HTTP.
+func respostaErroChaveamento(c *gin.Context, err error, contexto string) {
+	switch {
+	case errors.Is(err, models.ErrInscricoesInsuficientesChave), errors.Is(err, repository.ErrModalidadesMistas),
+		errors.Is(err, repository.ErrConfrontoVazio), errors.Is(err, repository.ErrClassificadosInsuficientes):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, repository.ErrFaseDeGruposPendente):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, repository.ErrGruposNaoEncontrados):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, pgx.ErrNoRows):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chaveamento não encontrado"})
+		return
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503": // foreign_key_violation
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido fornecido. O torneio ou a categoria especificada não existe."})
+			return
+		case "23505": // unique_violation
+			c.JSON(http.StatusConflict, gin.H{"error": "Já existe um chaveamento para esta categoria do torneio."})
+			return
+		}
+	}
+
+	log.Printf("Erro ao %s: %v", contexto, err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro interno ao processar o chaveamento."})
+}
+
+// CreateChaveamento godoc
+//
+//	@Summary		Gera o chaveamento de uma categoria
+//	@Description	Sorteia a chave de eliminatória simples de uma categoria do torneio. As inscrições são ordenadas pelo rating e posicionadas como cabeças de chave (1 e 2 em metades opostas); quando a quantidade de inscrições não é potência de 2, os melhores colocados recebem "bye". Os vencedores avançam automaticamente quando o resultado de um jogo é registrado.
+//	@Tags			Chaveamentos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int								true	"ID do Torneio"
+//	@Param			input	body		models.CriarChaveamentoInput	true	"Categoria do chaveamento"
+//	@Success		201		{object}	models.Chaveamento
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/torneios/{id}/chaveamento [post]
+func (h *ChaveamentoHandler) CreateChaveamento(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+
+	var input models.CriarChaveamentoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	chave, err := h.repo.Create(c.Request.Context(), torneioID, input)
+	if err != nil {
+		respostaErroChaveamento(c, err, "gerar chaveamento")
+		return
+	}
+
+	c.JSON(http.StatusCreated, chave)
+}
+
+// GetChaveamento godoc
+//
+//	@Summary		Busca o chaveamento de uma categoria
+//	@Description	Retorna a chave de eliminatória simples de uma categoria do torneio, com todas as partidas por rodada e posição.
+//	@Tags			Chaveamentos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int	true	"ID do Torneio"
+//	@Param			id_categoria	query		int	true	"ID da Categoria"
+//	@Success		200				{object}	models.Chaveamento
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/torneios/{id}/chaveamento [get]
+func (h *ChaveamentoHandler) GetChaveamento(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+	categoriaID, err := strconv.Atoi(c.Query("id_categoria"))
+	if err != nil || categoriaID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da categoria inválido"})
+		return
+	}
+
+	chave, err := h.repo.FindByTorneioCategoria(c.Request.Context(), torneioID, categoriaID)
+	if err != nil {
+		respostaErroChaveamento(c, err, "buscar chaveamento")
+		return
+	}
+
+	c.JSON(http.StatusOK, chave)
+}
+
+// CreatePlayoffs godoc
+//
+//	@Summary		Gera a fase eliminatória a partir dos grupos
+//	@Description	Coleta os classificados de todos os grupos de uma categoria (todos os jogos da fase de grupos precisam estar encerrados) e monta a chave eliminatória cruzando primeiros e segundos colocados de grupos diferentes (A1 x B2, B1 x A2, ...), criando os jogos da primeira rodada.
+//	@Tags			Chaveamentos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int							true	"ID do Torneio"
+//	@Param			input	body		models.CriarPlayoffsInput	true	"Categoria e quantidade de classificados por grupo"
+//	@Success		201		{object}	models.Chaveamento
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/torneios/{id}/playoffs [post]
+func (h *ChaveamentoHandler) CreatePlayoffs(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+
+	var input models.CriarPlayoffsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	chave, err := h.repo.CreatePlayoffs(c.Request.Context(), torneioID, input)
+	if err != nil {
+		respostaErroChaveamento(c, err, "gerar fase eliminatória")
+		return
+	}
+
+	c.JSON(http.StatusCreated, chave)
+}
@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"competitions/middleware"
+	"competitions/models"
+	"competitions/repository"
+	"competitions/validation"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CircuitoHandler encapsula a lógica para as rotas de circuitos (temporadas de ranking).
+type CircuitoHandler struct {
+	repo repository.CircuitoRepository
+}
+
+// NewCircuitoHandler cria uma nova instância de CircuitoHandler com o repositório fornecido.
+func NewCircuitoHandler(repo repository.CircuitoRepository) *CircuitoHandler {
+	return &CircuitoHandler{repo: repo}
+}
+
+// CreateCircuito godoc
+//
+//	@Summary		Cria um novo circuito
+//	@Description	Cria um circuito (temporada) de um esporte com a tabela de pontos por fase alcançada. Sem tabela informada, é usada a tabela padrão (campeão 100, finalista 70, semifinal 45, quartas 25, oitavas 15). O usuário autenticado é registrado como dono do circuito.
+//	@Tags			Circuitos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			circuito	body		models.CircuitoInput	true	"Dados do circuito"
+//	@Success		201			{object}	models.Circuito
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/circuitos [post]
+func (h *CircuitoHandler) CreateCircuito(c *gin.Context) {
+	var input models.CircuitoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	circuito, err := h.repo.Create(c.Request.Context(), input, middleware.UsuarioID(c))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido fornecido. O esporte especificado não existe."})
+			return
+		}
+		log.Printf("Erro ao criar circuito: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro interno ao criar o circuito."})
+		return
+	}
+
+	c.JSON(http.StatusCreated, circuito)
+}
+
+// GetCircuitos godoc
+//
+//	@Summary		Lista os circuitos
+//	@Description	Retorna os circuitos com as suas tabelas de pontos, opcionalmente filtrados por esporte e temporada.
+//	@Tags			Circuitos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id_esporte	query		int	false	"ID do Esporte"
+//	@Param			temporada	query		int	false	"Temporada (ano)"
+//	@Success		200			{array}		models.Circuito
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/circuitos [get]
+func (h *CircuitoHandler) GetCircuitos(c *gin.Context) {
+	esporteID, temporada := 0, 0
+	if valor := c.Query("id_esporte"); valor != "" {
+		id, err := strconv.Atoi(valor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro id_esporte inválido"})
+			return
+		}
+		esporteID = id
+	}
+	if valor := c.Query("temporada"); valor != "" {
+		ano, err := strconv.Atoi(valor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro temporada inválido"})
+			return
+		}
+		temporada = ano
+	}
+
+	circuitos, err := h.repo.FindAll(c.Request.Context(), esporteID, temporada)
+	if err != nil {
+		log.Printf("Erro ao buscar circuitos: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar os circuitos."})
+		return
+	}
+
+	c.JSON(http.StatusOK, circuitos)
+}
+
+// GetCircuitoByID godoc
+//
+//	@Summary		Busca um circuito por ID
+//	@Description	Retorna um circuito com a sua tabela de pontos e os seus torneios.
+//	@Tags			Circuitos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Circuito"
+//	@Success		200	{object}	models.Circuito
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/circuitos/{id} [get]
+func (h *CircuitoHandler) GetCircuitoByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	circuito, err := h.repo.FindByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Circuito não encontrado"})
+			return
+		}
+		log.Printf("Erro ao buscar circuito por ID %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar o circuito."})
+		return
+	}
+
+	c.JSON(http.StatusOK, circuito)
+}
+
+// UpdateCircuito godoc
+//
+//	@Summary		Atualiza um circuito
+//	@Description	Altera os dados de um circuito. Se a tabela de pontos for informada, ela substitui a atual; se for omitida, a tabela atual é mantida. Restrito ao criador do circuito e aos administradores.
+//	@Tags			Circuitos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int						true	"ID do Circuito"
+//	@Param			circuito	body		models.CircuitoInput	true	"Dados do circuito"
+//	@Success		200			{object}	SuccessResponse
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/circuitos/{id} [put]
+func (h *CircuitoHandler) UpdateCircuito(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input models.CircuitoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	rowsAffected, err := h.repo.Update(c.Request.Context(), id, input)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido fornecido. O esporte especificado não existe."})
+			return
+		}
+		log.Printf("Erro ao atualizar circuito %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao atualizar o circuito."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Circuito não encontrado para atualizar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Circuito atualizado com sucesso"})
+}
+
+// DeleteCircuito godoc
+//
+//	@Summary		Remove um circuito
+//	@Description	Remove um circuito e a sua tabela de pontos. Os torneios do circuito não são afetados. Restrito ao criador do circuito e aos administradores.
+//	@Tags			Circuitos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Circuito"
+//	@Success		200	{object}	SuccessResponse
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/circuitos/{id} [delete]
+func (h *CircuitoHandler) DeleteCircuito(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	rowsAffected, err := h.repo.Delete(c.Request.Context(), id)
+	if err != nil {
+		log.Printf("Erro ao remover circuito %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao remover o circuito."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Circuito não encontrado para remover"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Circuito removido com sucesso"})
+}
+
+// AdicionarTorneioCircuito godoc
+//
+//	@Summary		Inclui um torneio no circuito
+//	@Description	Inclui um torneio no circuito. O torneio precisa ser do mesmo esporte do circuito. Restrito ao criador do circuito e aos administradores, e o usuário também precisa organizar o torneio incluído.
+//	@Tags			Circuitos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int							true	"ID do Circuito"
+//	@Param			torneio	body		models.TorneioCircuitoInput	true	"Torneio a ser incluído"
+//	@Success		201		{object}	SuccessResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/circuitos/{id}/torneios [post]
+func (h *CircuitoHandler) AdicionarTorneioCircuito(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input models.TorneioCircuitoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	// O torneio só pode ser incluído por quem também o organiza.
+	if !middleware.AutorizarOrganizador(c, middleware.GerenciarTorneios, h.repo.EhOrganizadorTorneio, input.TorneioID) {
+		return
+	}
+
+	err = h.repo.AdicionarTorneio(c.Request.Context(), id, input)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Circuito ou torneio não encontrado"})
+			return
+		}
+		if errors.Is(err, repository.ErrEsporteCircuitoDiferente) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			c.JSON(http.StatusConflict, gin.H{"error": "O torneio já faz parte deste circuito."})
+			return
+		}
+		log.Printf("Erro ao incluir torneio %d no circuito %d: %v", input.TorneioID, id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao incluir o torneio no circuito."})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Torneio incluído no circuito com sucesso"})
+}
+
+// RemoverTorneioCircuito godoc
+//
+//	@Summary		Retira um torneio do circuito
+//	@Description	Retira um torneio do circuito; os seus resultados deixam de contar na classificação. Restrito ao criador do circuito e aos administradores.
+//	@Tags			Circuitos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int	true	"ID do Circuito"
+//	@Param			id_torneio	path		int	true	"ID do Torneio"
+//	@Success		200			{object}	SuccessResponse
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/circuitos/{id}/torneios/{id_torneio} [delete]
+func (h *CircuitoHandler) RemoverTorneioCircuito(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+	torneioID, err := strconv.Atoi(c.Param("id_torneio"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+
+	rowsAffected, err := h.repo.RemoverTorneio(c.Request.Context(), id, torneioID)
+	if err != nil {
+		log.Printf("Erro ao retirar torneio %d do circuito %d: %v", torneioID, id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao retirar o torneio do circuito."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Torneio não encontrado neste circuito"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Torneio retirado do circuito com sucesso"})
+}
+
+// SomenteCriador restringe a rota, identificada pelo ID do circuito, ao criador do circuito e
+// aos administradores.
+func (h *CircuitoHandler) SomenteCriador(acao middleware.Acao) gin.HandlerFunc {
+	return middleware.RequerOrganizador(acao, h.repo.EhCriador)
+}
+
+// GetClassificacaoCircuito godoc
+//
+//	@Summary		Classificação de um circuito
+//	@Description	Calcula a classificação de uma categoria do circuito a partir das colocações finais nas chaves dos seus torneios (apenas chaves com a final decidida). Cada jogador soma os pontos da fase alcançada em cada torneio; com melhores_resultados definido, apenas os N melhores resultados contam. Em duplas, cada jogador recebe os pontos da dupla.
+//	@Tags			Circuitos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int		true	"ID do Circuito"
+//	@Param			id_categoria	query		int		true	"ID da Categoria"
+//	@Param			tipo_modalidade	query		string	false	"Modalidade (simples ou duplas, padrão: simples)"
+//	@Success		200				{object}	models.ClassificacaoCircuito
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/circuitos/{id}/classificacao [get]
+func (h *CircuitoHandler) GetClassificacaoCircuito(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+	categoriaID, err := strconv.Atoi(c.Query("id_categoria"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro id_categoria inválido ou ausente"})
+		return
+	}
+	modalidade := c.DefaultQuery("tipo_modalidade", "simples")
+	if modalidade != "simples" && modalidade != "duplas" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro tipo_modalidade deve ser 'simples' ou 'duplas'"})
+		return
+	}
+
+	classificacao, err := h.repo.FindClassificacao(c.Request.Context(), id, categoriaID, modalidade)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Circuito não encontrado"})
+			return
+		}
+		log.Printf("Erro ao calcular classificação do circuito %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao calcular a classificação do circuito."})
+		return
+	}
+
+	c.JSON(http.StatusOK, classificacao)
+}
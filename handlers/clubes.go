@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"competitions/middleware"
+	"competitions/models"
+	"competitions/repository"
+	"competitions/validation"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ClubeHandler encapsula a lógica para as rotas de clubes.
+type ClubeHandler struct {
+	repo repository.ClubeRepository
+}
+
+// NewClubeHandler cria uma nova instância de ClubeHandler com o repositório fornecido.
+func NewClubeHandler(repo repository.ClubeRepository) *ClubeHandler {
+	return &ClubeHandler{repo: repo}
+}
+
+// erroReferenciaClube é a resposta para um clube com cidade, estado, país ou jogador responsável inexistente.
+const erroReferenciaClube = "ID inválido fornecido. A cidade, o estado, o país ou o jogador responsável especificado não existe."
+
+// CreateClube godoc
+//
+//	@Summary		Cria um novo clube
+//	@Description	Cria um novo clube. O usuário autenticado é registrado como criador e organizador do clube.
+//	@Tags			Clubes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			input	body		models.ClubeInput	true	"Dados do Clube"
+//	@Success		201		{object}	models.Clube
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/clubes [post]
+func (h *ClubeHandler) CreateClube(c *gin.Context) {
+	var input models.ClubeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	clube, err := h.repo.Create(c.Request.Context(), input, middleware.UsuarioID(c))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			c.JSON(http.StatusBadRequest, gin.H{"error": erroReferenciaClube})
+			return
+		}
+		log.Printf("Erro ao criar clube: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro interno ao criar o clube."})
+		return
+	}
+
+	c.JSON(http.StatusCreated, clube)
+}
+
+// GetClubes godoc
+//
+//	@Summary		Lista todos os clubes
+//	@Description	Retorna todos os clubes, ordenados pelo nome.
+//	@Tags			Clubes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		models.Clube
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/clubes [get]
+func (h *ClubeHandler) GetClubes(c *gin.Context) {
+	clubes, err := h.repo.FindAll(c.Request.Context())
+	if err != nil {
+		log.Printf("Erro ao buscar clubes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar os clubes."})
+		return
+	}
+
+	c.JSON(http.StatusOK, clubes)
+}
+
+// GetClubeByID godoc
+//
+//	@Summary		Busca um clube por ID
+//	@Description	Retorna um único clube com base no ID fornecido.
+//	@Tags			Clubes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Clube"
+//	@Success		200	{object}	models.Clube
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/clubes/{id} [get]
+func (h *ClubeHandler) GetClubeByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	clube, err := h.repo.FindByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Clube não encontrado"})
+			return
+		}
+		log.Printf("Erro ao buscar clube por ID %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar o clube."})
+		return
+	}
+
+	c.JSON(http.StatusOK, clube)
+}
+
+// UpdateClube godoc
+//
+//	@Summary		Atualiza um clube existente
+//	@Description	Atualiza os dados de um clube. Apenas o criador, os coorganizadores do clube e administradores podem alterá-lo.
+//	@Tags			Clubes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"ID do Clube"
+//	@Param			input	body		models.ClubeInput	true	"Dados do Clube"
+//	@Success		200		{object}	SuccessResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/clubes/{id} [put]
+func (h *ClubeHandler) UpdateClube(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input models.ClubeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	rowsAffected, err := h.repo.Update(c.Request.Context(), id, input)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			c.JSON(http.StatusBadRequest, gin.H{"error": erroReferenciaClube})
+			return
+		}
+		log.Printf("Erro ao atualizar clube %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao atualizar o clube."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Clube não encontrado para atualizar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Clube atualizado com sucesso"})
+}
+
+// DeleteClube godoc
+//
+//	@Summary		Deleta um clube
+//	@Description	Deleta um clube, junto com os seus membros e coorganizadores. Apenas o criador do clube e administradores podem deletá-lo.
+//	@Tags			Clubes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Clube"
+//	@Success		200	{object}	SuccessResponse
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/clubes/{id} [delete]
+func (h *ClubeHandler) DeleteClube(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	rowsAffected, err := h.repo.Delete(c.Request.Context(), id)
+	if err != nil {
+		log.Printf("Erro ao deletar clube %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao deletar o clube."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Clube não encontrado para deletar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Clube deletado com sucesso"})
+}
+
+// SomenteOrganizadores restringe a rota, identificada pelo ID do clube, ao criador do clube,
+// aos seus coorganizadores e aos administradores.
+func (h *ClubeHandler) SomenteOrganizadores(acao middleware.Acao) gin.HandlerFunc {
+	return middleware.RequerOrganizador(acao, h.repo.EhOrganizador)
+}
+
+// SomenteCriador restringe a rota, identificada pelo ID do clube, ao criador do clube e aos
+// administradores.
+func (h *ClubeHandler) SomenteCriador(acao middleware.Acao) gin.HandlerFunc {
+	return middleware.RequerOrganizador(acao, h.repo.EhCriador)
+}
+
+// GetOrganizadores godoc
+//
+//	@Summary		Lista os organizadores de um clube
+//	@Description	Retorna o criador do clube, seguido dos coorganizadores, que podem alterar o clube.
+//	@Tags			Clubes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Clube"
+//	@Success		200	{array}		models.OrganizadorClube
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/clubes/{id}/organizadores [get]
+func (h *ClubeHandler) GetOrganizadores(c *gin.Context) {
+	clubeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do clube inválido"})
+		return
+	}
+
+	organizadores, err := h.repo.FindOrganizadores(c.Request.Context(), clubeID)
+	if err != nil {
+		log.Printf("Erro ao buscar organizadores do clube %d: %v", clubeID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar os organizadores."})
+		return
+	}
+
+	c.JSON(http.StatusOK, organizadores)
+}
+
+// AdicionarOrganizador godoc
+//
+//	@Summary		Adiciona um coorganizador ao clube
+//	@Description	Concede a um usuário a organização do clube. Apenas o criador do clube e administradores podem adicionar coorganizadores.
+//	@Tags			Clubes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"ID do Clube"
+//	@Param			input	body		models.OrganizadorInput	true	"Usuário a ser adicionado"
+//	@Success		201		{object}	SuccessResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/clubes/{id}/organizadores [post]
+func (h *ClubeHandler) AdicionarOrganizador(c *gin.Context) {
+	clubeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do clube inválido"})
+		return
+	}
+
+	var input models.OrganizadorInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	if err := h.repo.AdicionarOrganizador(c.Request.Context(), clubeID, input.UsuarioID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+		log.Printf("Erro ao adicionar organizador %d ao clube %d: %v", input.UsuarioID, clubeID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao adicionar o organizador."})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Organizador adicionado com sucesso"})
+}
+
+// RemoverOrganizador godoc
+//
+//	@Summary		Remove um coorganizador do clube
+//	@Description	Revoga a organização do clube de um coorganizador. O criador do clube não pode ser removido. Apenas o criador do clube e administradores podem remover coorganizadores.
+//	@Tags			Clubes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int	true	"ID do Clube"
+//	@Param			id_usuario	path		int	true	"ID do Usuário"
+//	@Success		200			{object}	SuccessResponse
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/clubes/{id}/organizadores/{id_usuario} [delete]
+func (h *ClubeHandler) RemoverOrganizador(c *gin.Context) {
+	clubeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do clube inválido"})
+		return
+	}
+	usuarioID, err := strconv.Atoi(c.Param("id_usuario"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do usuário inválido"})
+		return
+	}
+
+	rowsAffected, err := h.repo.RemoverOrganizador(c.Request.Context(), clubeID, usuarioID)
+	if err != nil {
+		log.Printf("Erro ao remover organizador %d do clube %d: %v", usuarioID, clubeID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao remover o organizador."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Organizador não encontrado"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Organizador removido com sucesso"})
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"competitions/middleware"
+	"competitions/models"
+	"competitions/repository"
+	"competitions/validation"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DuplaHandler encapsula a lógica para as rotas de duplas.
+type DuplaHandler struct {
+	repo repository.DuplaRepository
+}
+
+// NewDuplaHandler cria uma nova instância de DuplaHandler com o repositório fornecido.
+func NewDuplaHandler(repo repository.DuplaRepository) *DuplaHandler {
+	return &DuplaHandler{repo: repo}
+}
+
+// SomenteIntegrantes restringe a rota, identificada pelo ID da dupla, aos jogadores da
+// dupla e aos administradores.
+func (h *DuplaHandler) SomenteIntegrantes(acao middleware.Acao) gin.HandlerFunc {
+	return middleware.RequerOrganizador(acao, h.repo.IntegraDupla)
+}
+
+// CreateDupla godoc
+//
+//	@Summary		Cria uma nova dupla
+//	@Description	Forma uma dupla com dois jogadores. A ordem dos jogadores é normalizada automaticamente (o menor ID é gravado como jogador A), e não é possível criar duas duplas com os mesmos jogadores. Apenas um dos jogadores da dupla ou um administrador pode formá-la.
+//	@Tags			Duplas
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dupla	body		models.DuplaInput	true	"Jogadores e nome da dupla"
+//	@Success		201		{object}	models.DuplaDetalhes
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/duplas [post]
+func (h *DuplaHandler) CreateDupla(c *gin.Context) {
+	var input models.DuplaInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	// Um jogador só forma duplas das quais faz parte.
+	if !middleware.PodeExecutar(c, middleware.Administrar) {
+		integra, err := h.repo.EhJogadorDoUsuario(c.Request.Context(), middleware.UsuarioID(c), input.JogadorAID, input.JogadorBID)
+		if err != nil {
+			log.Printf("Erro ao verificar os jogadores da dupla: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao verificar as permissões."})
+			return
+		}
+		if !integra {
+			c.JSON(http.StatusForbidden, gin.H{"error": middleware.MensagemPermissaoNegada})
+			return
+		}
+	}
+
+	dupla, err := h.repo.Create(c.Request.Context(), input)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23503": // foreign_key_violation
+				c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido fornecido. Um dos jogadores especificados não existe."})
+				return
+			case "23505": // unique_violation
+				c.JSON(http.StatusConflict, gin.H{"error": "Já existe uma dupla formada por estes jogadores."})
+				return
+			}
+		}
+		log.Printf("Erro ao criar dupla: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro interno ao criar a dupla."})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dupla)
+}
+
+// GetDuplas godoc
+//
+//	@Summary		Lista as duplas
+//	@Description	Retorna as duplas cadastradas. Com o parâmetro id_jogador, retorna apenas as duplas das quais o jogador faz parte.
+//	@Tags			Duplas
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id_jogador	query		int	false	"ID do Jogador"
+//	@Success		200			{array}		models.DuplaDetalhes
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/duplas [get]
+func (h *DuplaHandler) GetDuplas(c *gin.Context) {
+	jogadorID := 0
+	if valor := c.Query("id_jogador"); valor != "" {
+		id, err := strconv.Atoi(valor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro id_jogador inválido"})
+			return
+		}
+		jogadorID = id
+	}
+
+	duplas, err := h.repo.FindAll(c.Request.Context(), jogadorID)
+	if err != nil {
+		log.Printf("Erro ao buscar duplas: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar as duplas."})
+		return
+	}
+
+	c.JSON(http.StatusOK, duplas)
+}
+
+// GetDuplaByID godoc
+//
+//	@Summary		Busca uma dupla por ID
+//	@Description	Retorna uma única dupla, com os seus jogadores, com base no ID fornecido.
+//	@Tags			Duplas
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID da Dupla"
+//	@Success		200	{object}	models.DuplaDetalhes
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/duplas/{id} [get]
+func (h *DuplaHandler) GetDuplaByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	dupla, err := h.repo.FindByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Dupla não encontrada"})
+			return
+		}
+		log.Printf("Erro ao buscar dupla por ID %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar a dupla."})
+		return
+	}
+
+	c.JSON(http.StatusOK, dupla)
+}
+
+// RenomearDupla godoc
+//
+//	@Summary		Renomeia uma dupla
+//	@Description	Altera o nome de uma dupla. Enviar nome_dupla nulo remove o nome.
+//	@Tags			Duplas
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int							true	"ID da Dupla"
+//	@Param			dupla	body		models.RenomearDuplaInput	true	"Novo nome da dupla"
+//	@Success		200		{object}	SuccessResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/duplas/{id} [put]
+func (h *DuplaHandler) RenomearDupla(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input models.RenomearDuplaInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	rowsAffected, err := h.repo.Rename(c.Request.Context(), id, input)
+	if err != nil {
+		log.Printf("Erro ao renomear dupla %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao renomear a dupla."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Dupla não encontrada para renomear"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dupla renomeada com sucesso"})
+}
+
+// DeleteDupla godoc
+//
+//	@Summary		Desfaz uma dupla
+//	@Description	Remove uma dupla. Duplas com inscrições em torneios não podem ser desfeitas.
+//	@Tags			Duplas
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID da Dupla"
+//	@Success		200	{object}	SuccessResponse
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/duplas/{id} [delete]
+func (h *DuplaHandler) DeleteDupla(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	rowsAffected, err := h.repo.Delete(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplaComInscricoes) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Erro ao desfazer dupla %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao desfazer a dupla."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Dupla não encontrada para desfazer"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dupla desfeita com sucesso"})
+}
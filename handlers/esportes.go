@@ -337,3 +337,88 @@ func (h *EsporteHandler) DeleteEsporte(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Esporte deletado com sucesso"})
 }
+
+// GetConfiguracaoRating godoc
+//
+//	@Summary		Busca a configuração de rating de um esporte
+//	@Description	Retorna o algoritmo (Elo ou Glicko-2) e os parâmetros usados para atualizar o rating dos jogadores nos jogos do esporte.
+//	@Tags			Esportes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Esporte"
+//	@Success		200	{object}	models.ConfiguracaoRating
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/esportes/{id}/rating [get]
+func (h *EsporteHandler) GetConfiguracaoRating(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	cfg, err := h.repo.FindConfiguracaoRating(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Esporte não encontrado"})
+			return
+		}
+		log.Printf("Erro ao buscar configuração de rating do esporte %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar a configuração de rating."})
+		return
+	}
+
+	c.JSON(http.StatusOK, cfg)
+}
+
+// UpdateConfiguracaoRating godoc
+//
+//	@Summary		Altera a configuração de rating de um esporte
+//	@Description	Define o algoritmo (elo ou glicko2) e os parâmetros usados para atualizar o rating dos jogadores. A alteração vale para os resultados registrados a partir de então.
+//	@Tags			Esportes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int								true	"ID do Esporte"
+//	@Param			input	body		models.ConfiguracaoRatingInput	true	"Algoritmo e parâmetros"
+//	@Success		200		{object}	SuccessResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/esportes/{id}/rating [put]
+func (h *EsporteHandler) UpdateConfiguracaoRating(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input models.ConfiguracaoRatingInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	rowsAffected, err := h.repo.UpdateConfiguracaoRating(c.Request.Context(), id, input)
+	if err != nil {
+		log.Printf("Erro ao atualizar configuração de rating do esporte %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao atualizar a configuração de rating."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Esporte não encontrado para atualizar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Configuração de rating atualizada com sucesso"})
+}
@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"competitions/middleware"
 	"competitions/models"
 	"competitions/repository"
+	"errors"
+	"log"
 	"net/http"
-	"sort"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // GrupoHandler encapsula a lógica para as rotas de grupos.
@@ -20,6 +23,12 @@ func NewGrupoHandler(repo repository.GrupoRepository) *GrupoHandler {
 	return &GrupoHandler{repo: repo}
 }
 
+// SomenteOrganizadores restringe a rota, identificada pelo ID do grupo, aos organizadores
+// do torneio do grupo e aos administradores.
+func (h *GrupoHandler) SomenteOrganizadores(acao middleware.Acao) gin.HandlerFunc {
+	return middleware.RequerOrganizador(acao, h.repo.EhOrganizador)
+}
+
 // CriarGrupos é o handler para a criação de grupos em um torneio.
 func (h *GrupoHandler) CreateGrupos(c *gin.Context) {
 	torneioID, err := strconv.Atoi(c.Param("id"))
@@ -56,45 +65,129 @@ func (h *GrupoHandler) DefinirVencedoresGrupo(c *gin.Context) {
 		return
 	}
 
-	estatisticas, err := h.repo.GetEstatisticasGrupo(c.Request.Context(), grupoID)
+	classificacao, err := h.repo.GetClassificacaoGrupo(c.Request.Context(), grupoID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Grupo não encontrado"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	if len(estatisticas) < 2 {
+	if len(classificacao.Linhas) < 2 {
 		c.JSON(http.StatusOK, gin.H{"message": "Não há jogadores suficientes para definir vencedores"})
 		return
 	}
 
-	// Ordena os jogadores com base nos critérios
-	sort.SliceStable(estatisticas, func(i, j int) bool {
-		if estatisticas[i].SetsGanhos != estatisticas[j].SetsGanhos {
-			return estatisticas[i].SetsGanhos > estatisticas[j].SetsGanhos
-		}
-		return estatisticas[i].PontosGanhos > estatisticas[j].PontosGanhos
-	})
-
-	// Define os vencedores
+	// Os dois primeiros colocados, com o critério de desempate que definiu cada posição.
 	vencedores := make([]models.VencedorGrupo, 2)
-	for i := 0; i < 2; i++ {
-		playerStats := estatisticas[i]
-		criterio := "Total de Sets Ganhos"
-		// Verifica se houve empate nos sets e o desempate foi por pontos
-		if i > 0 && playerStats.SetsGanhos == estatisticas[i-1].SetsGanhos {
-			criterio = "Total de Pontos Conquistados"
-		}
-
+	for i, linha := range classificacao.Linhas[:2] {
 		vencedores[i] = models.VencedorGrupo{
-			Posicao:      i + 1,
-			JogadorID:    playerStats.JogadorID,
-			NomeJogador:  playerStats.NomeJogador,
-			Criterio:     criterio,
-			SetsGanhos:   playerStats.SetsGanhos,
-			PontosGanhos: playerStats.PontosGanhos,
+			Posicao:      linha.Posicao,
+			InscricaoID:  linha.InscricaoID,
+			NomeJogador:  linha.Nome,
+			Criterio:     linha.CriterioDesempate,
+			SetsGanhos:   linha.SetsPro,
+			PontosGanhos: linha.PontosPro,
+		}
+		if linha.JogadorID != nil {
+			vencedores[i].JogadorID = *linha.JogadorID
 		}
 	}
 
 	c.JSON(http.StatusOK, models.ResultadoVencedores{Vencedores: vencedores})
 }
 
+// classificadosPorGrupo lê o parâmetro de consulta 'classificados' (padrão: 2).
+func classificadosPorGrupo(c *gin.Context) (int, bool) {
+	quantidade, err := strconv.Atoi(c.DefaultQuery("classificados", "2"))
+	if err != nil || quantidade < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Quantidade de classificados inválida"})
+		return 0, false
+	}
+	return quantidade, true
+}
+
+// GetClassificacaoGrupo godoc
+//
+//	@Summary		Classificação completa de um grupo
+//	@Description	Retorna, para cada participante do grupo, jogos disputados, vitórias, derrotas, sets e pontos pró/contra, a posição com o critério de desempate aplicado e se está classificado.
+//	@Tags			Grupos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int	true	"ID do Grupo"
+//	@Param			classificados	query		int	false	"Quantidade de classificados por grupo (padrão: 2)"
+//	@Success		200				{object}	models.ClassificacaoGrupo
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/grupos/{id}/classificacao [get]
+func (h *GrupoHandler) GetClassificacaoGrupo(c *gin.Context) {
+	grupoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do grupo inválido"})
+		return
+	}
+	quantidade, ok := classificadosPorGrupo(c)
+	if !ok {
+		return
+	}
+
+	classificacao, err := h.repo.GetClassificacaoGrupo(c.Request.Context(), grupoID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Grupo não encontrado"})
+			return
+		}
+		log.Printf("Erro ao calcular classificação do grupo %d: %v", grupoID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro interno ao calcular a classificação."})
+		return
+	}
+
+	classificacao.MarcarClassificados(quantidade)
+	c.JSON(http.StatusOK, classificacao)
+}
+
+// GetClassificacaoCategoria godoc
+//
+//	@Summary		Classificação de todos os grupos de uma categoria
+//	@Description	Retorna a classificação completa de cada grupo de uma categoria do torneio em uma única chamada.
+//	@Tags			Grupos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int	true	"ID do Torneio"
+//	@Param			id_categoria	query		int	true	"ID da Categoria"
+//	@Param			classificados	query		int	false	"Quantidade de classificados por grupo (padrão: 2)"
+//	@Success		200				{array}		models.ClassificacaoGrupo
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/torneios/{id}/classificacao [get]
+func (h *GrupoHandler) GetClassificacaoCategoria(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+	categoriaID, err := strconv.Atoi(c.Query("id_categoria"))
+	if err != nil || categoriaID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da categoria inválido"})
+		return
+	}
+	quantidade, ok := classificadosPorGrupo(c)
+	if !ok {
+		return
+	}
+
+	tabelas, err := h.repo.GetClassificacaoCategoria(c.Request.Context(), torneioID, categoriaID)
+	if err != nil {
+		log.Printf("Erro ao calcular classificação da categoria %d do torneio %d: %v", categoriaID, torneioID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro interno ao calcular a classificação."})
+		return
+	}
+
+	for i := range tabelas {
+		tabelas[i].MarcarClassificados(quantidade)
+	}
+	c.JSON(http.StatusOK, tabelas)
+}
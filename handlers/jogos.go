@@ -0,0 +1,674 @@
+package handlers
+
+import (
+	"competitions/middleware"
+	"competitions/models"
+	"competitions/regras"
+	"competitions/repository"
+	"competitions/validation"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// JogoHandler encapsula a lógica para as rotas de jogos (partidas).
+type JogoHandler struct {
+	repo repository.JogoRepository
+}
+
+// NewJogoHandler cria uma nova instância de JogoHandler com o repositório fornecido.
+func NewJogoHandler(repo repository.JogoRepository) *JogoHandler {
+	return &JogoHandler{repo: repo}
+}
+
+// SomenteOrganizadores restringe a rota, identificada pelo ID do jogo, aos organizadores
+// do torneio do jogo e aos administradores.
+func (h *JogoHandler) SomenteOrganizadores(acao middleware.Acao) gin.HandlerFunc {
+	return middleware.RequerOrganizador(acao, h.repo.EhOrganizador)
+}
+
+// respostaErroJogo traduz os erros de escrita de jogos em respostas HTTP.
+// Violações de chave estrangeira e de constraints de verificação indicam dados
+// inválidos enviados pelo cliente e são retornadas como 400.
+func respostaErroJogo(c *gin.Context, err error, contexto string) {
+	switch {
+	case errors.Is(err, repository.ErrJogoParticipantesIguais), errors.Is(err, repository.ErrLadoVencedorInvalido),
+		errors.Is(err, models.ErrSetEmpatado), errors.Is(err, models.ErrPlacarEmpatado),
+		errors.Is(err, regras.ErrSetInvalido), errors.Is(err, regras.ErrSetsInsuficientes),
+		errors.Is(err, regras.ErrSetsAposDecisao), errors.Is(err, regras.ErrQuantidadeSetsMaxima),
+		errors.Is(err, regras.ErrEventoInvalido), errors.Is(err, repository.ErrEsporteSemRegras):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, repository.ErrGrupoSemParticipantes), errors.Is(err, models.ErrJanelaDiariaInvalida):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, repository.ErrGruposNaoEncontrados):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, repository.ErrJogoEncerrado), errors.Is(err, repository.ErrJogosJaGerados),
+		errors.Is(err, repository.ErrChaveamentoAvancado), errors.Is(err, regras.ErrPartidaEncerrada),
+		errors.Is(err, repository.ErrSemEventosPontuacao), errors.Is(err, repository.ErrPartidaNaoDecidida),
+		errors.Is(err, repository.ErrResultadoComSets), errors.Is(err, repository.ErrExclusaoJogoEncerrado):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, pgx.ErrNoRows):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Jogo não encontrado"})
+		return
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503": // foreign_key_violation
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido fornecido. O torneio, grupo, rodada, inscrição ou dupla especificado não existe."})
+			return
+		case "23514": // check_violation
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Os participantes informados não são consistentes com o tipo de modalidade do jogo."})
+			return
+		}
+	}
+
+	log.Printf("Erro ao %s: %v", contexto, err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro interno ao processar o jogo."})
+}
+
+// CreateJogo godoc
+//
+//	@Summary		Cria um novo jogo
+//	@Description	Cria uma partida em um torneio, associada a um grupo e a uma rodada.
+//	@Tags			Jogos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			input	body		models.JogoInput	true	"Dados do Jogo"
+//	@Success		201		{object}	models.Jogo
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/jogos [post]
+func (h *JogoHandler) CreateJogo(c *gin.Context) {
+	var input models.JogoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	// Apenas os organizadores do torneio podem criar jogos nele.
+	if !middleware.AutorizarOrganizador(c, middleware.GerenciarTorneios, h.repo.EhOrganizadorTorneio, input.TorneioID) {
+		return
+	}
+
+	jogo, err := h.repo.Create(c.Request.Context(), input)
+	if err != nil {
+		respostaErroJogo(c, err, "criar jogo")
+		return
+	}
+
+	c.JSON(http.StatusCreated, jogo)
+}
+
+// GetJogos godoc
+//
+//	@Summary		Lista jogos
+//	@Description	Retorna os jogos cadastrados, com filtros opcionais por torneio, grupo, rodada e situação.
+//	@Tags			Jogos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id_torneio	query		int		false	"ID do Torneio"
+//	@Param			id_grupo	query		int		false	"ID do Grupo"
+//	@Param			id_rodada	query		int		false	"ID da Rodada"
+//	@Param			situacao	query		string	false	"Situação do Jogo (aguardando, em andamento, encerrado)"
+//	@Success		200			{array}		models.Jogo
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/jogos [get]
+func (h *JogoHandler) GetJogos(c *gin.Context) {
+	var filtro models.FiltroJogos
+	for param, destino := range map[string]*int{
+		"id_torneio": &filtro.TorneioID,
+		"id_grupo":   &filtro.GrupoID,
+		"id_rodada":  &filtro.RodadaID,
+	} {
+		valor := c.Query(param)
+		if valor == "" {
+			continue
+		}
+		id, err := strconv.Atoi(valor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro " + param + " inválido"})
+			return
+		}
+		*destino = id
+	}
+	filtro.Situacao = c.Query("situacao")
+
+	jogos, err := h.repo.FindAll(c.Request.Context(), filtro)
+	if err != nil {
+		log.Printf("Erro ao buscar jogos: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar os jogos."})
+		return
+	}
+
+	c.JSON(http.StatusOK, jogos)
+}
+
+// GetJogoByID godoc
+//
+//	@Summary		Busca um jogo por ID
+//	@Description	Retorna um único jogo com base no ID fornecido.
+//	@Tags			Jogos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Jogo"
+//	@Success		200	{object}	models.Jogo
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/jogos/{id} [get]
+func (h *JogoHandler) GetJogoByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	jogo, err := h.repo.FindByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Jogo não encontrado"})
+			return
+		}
+		log.Printf("Erro ao buscar jogo por ID %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar o jogo."})
+		return
+	}
+
+	c.JSON(http.StatusOK, jogo)
+}
+
+// UpdateJogo godoc
+//
+//	@Summary		Atualiza um jogo existente
+//	@Description	Atualiza participantes, rodada, horário e local de um jogo que ainda não foi encerrado.
+//	@Tags			Jogos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"ID do Jogo"
+//	@Param			input	body		models.JogoInput	true	"Dados do Jogo"
+//	@Success		200		{object}	SuccessResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/jogos/{id} [put]
+func (h *JogoHandler) UpdateJogo(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input models.JogoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	// O jogo só pode ser movido para um torneio que o usuário também organize.
+	if !middleware.AutorizarOrganizador(c, middleware.GerenciarTorneios, h.repo.EhOrganizadorTorneio, input.TorneioID) {
+		return
+	}
+
+	rowsAffected, err := h.repo.Update(c.Request.Context(), id, input)
+	if err != nil {
+		respostaErroJogo(c, err, "atualizar jogo")
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Jogo não encontrado para atualizar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Jogo atualizado com sucesso"})
+}
+
+// DeleteJogo godoc
+//
+//	@Summary		Deleta um jogo
+//	@Description	Remove um jogo e seus sets do sistema. Jogos encerrados retornam 409: o resultado já foi aplicado aos ratings e ao chaveamento e deve ser corrigido, não excluído.
+//	@Tags			Jogos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Jogo"
+//	@Success		200	{object}	SuccessResponse
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/jogos/{id} [delete]
+func (h *JogoHandler) DeleteJogo(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	rowsAffected, err := h.repo.Delete(c.Request.Context(), id)
+	if err != nil {
+		respostaErroJogo(c, err, "deletar jogo")
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Jogo não encontrado para deletar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Jogo deletado com sucesso"})
+}
+
+// RegistrarResultado godoc
+//
+//	@Summary		Registra o resultado de um jogo
+//	@Description	Define o lado vencedor de um jogo, preenchendo as colunas de vencedor/perdedor conforme a modalidade, e encerra a partida. Jogos com sets registrados retornam 409: o resultado deles deve ser corrigido em PUT /jogos/{id}/sets, que deriva o vencedor do placar.
+//	@Tags			Jogos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int							true	"ID do Jogo"
+//	@Param			input	body		models.ResultadoJogoInput	true	"Lado vencedor"
+//	@Success		200		{object}	models.Jogo
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/jogos/{id}/resultado [put]
+func (h *JogoHandler) RegistrarResultado(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input models.ResultadoJogoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	jogo, err := h.repo.RegistrarResultado(c.Request.Context(), id, input)
+	if err != nil {
+		respostaErroJogo(c, err, "registrar resultado do jogo")
+		return
+	}
+
+	c.JSON(http.StatusOK, jogo)
+}
+
+// GetSets godoc
+//
+//	@Summary		Lista os sets de um jogo
+//	@Description	Retorna o placar set a set de um jogo, ordenado pelo número do set.
+//	@Tags			Jogos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Jogo"
+//	@Success		200	{array}		models.Set
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/jogos/{id}/sets [get]
+func (h *JogoHandler) GetSets(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	sets, err := h.repo.FindSets(c.Request.Context(), id)
+	if err != nil {
+		log.Printf("Erro ao buscar sets do jogo %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar os sets."})
+		return
+	}
+
+	c.JSON(http.StatusOK, sets)
+}
+
+// SalvarSets godoc
+//
+//	@Summary		Registra ou corrige o placar de um jogo
+//	@Description	Substitui a lista completa de sets de um jogo, define o vencedor a partir dos sets e encerra o jogo em uma única transação. O placar é validado pelas regras do esporte (games ou pontos por set, vitória por dois de diferença, tiebreak, super tiebreak decisivo e melhor de N sets).
+//	@Tags			Jogos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"ID do Jogo"
+//	@Param			input	body		models.PlacarJogoInput	true	"Sets do jogo, na ordem em que foram disputados"
+//	@Success		200		{object}	models.PlacarJogo
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/jogos/{id}/sets [put]
+func (h *JogoHandler) SalvarSets(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input models.PlacarJogoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	placar, err := h.repo.SalvarSets(c.Request.Context(), id, input.Sets)
+	if err != nil {
+		respostaErroJogo(c, err, "salvar sets do jogo")
+		return
+	}
+
+	c.JSON(http.StatusOK, placar)
+}
+
+// GetPlacarAoVivo godoc
+//
+//	@Summary		Busca o placar ao vivo de um jogo
+//	@Description	Calcula o placar ponto a ponto de um jogo (sets encerrados, games e pontos em andamento) reproduzindo seus eventos de pontuação pelas regras do esporte e pelo formato de partida do torneio.
+//	@Tags			Jogos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Jogo"
+//	@Success		200	{object}	models.PlacarAoVivo
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/jogos/{id}/placar-ao-vivo [get]
+func (h *JogoHandler) GetPlacarAoVivo(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	placar, err := h.repo.FindPlacarAoVivo(c.Request.Context(), id)
+	if err != nil {
+		respostaErroJogo(c, err, "buscar placar ao vivo do jogo")
+		return
+	}
+
+	c.JSON(http.StatusOK, placar)
+}
+
+// RegistrarEvento godoc
+//
+//	@Summary		Registra um evento do placar ao vivo
+//	@Description	Acrescenta um ponto, falta ou let à pontuação ao vivo de um jogo e retorna o placar atualizado. Games, sets e tiebreaks são fechados automaticamente; o primeiro evento coloca o jogo em andamento. Nos esportes contados em games, a falta é de saque e duas seguidas dão o ponto ao adversário.
+//	@Tags			Jogos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int							true	"ID do Jogo"
+//	@Param			input	body		models.EventoPontuacaoInput	true	"Evento de pontuação"
+//	@Success		201		{object}	models.PlacarAoVivo
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/jogos/{id}/eventos [post]
+func (h *JogoHandler) RegistrarEvento(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input models.EventoPontuacaoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	placar, err := h.repo.RegistrarEvento(c.Request.Context(), id, input)
+	if err != nil {
+		respostaErroJogo(c, err, "registrar evento do jogo")
+		return
+	}
+
+	c.JSON(http.StatusCreated, placar)
+}
+
+// DesfazerUltimoEvento godoc
+//
+//	@Summary		Desfaz o último evento do placar ao vivo
+//	@Description	Remove o último evento de pontuação de um jogo ainda não encerrado e retorna o placar recalculado.
+//	@Tags			Jogos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Jogo"
+//	@Success		200	{object}	models.PlacarAoVivo
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/jogos/{id}/eventos/ultimo [delete]
+func (h *JogoHandler) DesfazerUltimoEvento(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	placar, err := h.repo.DesfazerUltimoEvento(c.Request.Context(), id)
+	if err != nil {
+		respostaErroJogo(c, err, "desfazer evento do jogo")
+		return
+	}
+
+	c.JSON(http.StatusOK, placar)
+}
+
+// EncerrarPlacarAoVivo godoc
+//
+//	@Summary		Encerra um jogo pelo placar ao vivo
+//	@Description	Grava os sets calculados pelo placar ao vivo e encerra o jogo com o vencedor da partida, atualizando ratings e chaveamento como no registro manual dos sets. A partida precisa estar decidida.
+//	@Tags			Jogos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Jogo"
+//	@Success		200	{object}	models.PlacarJogo
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/jogos/{id}/encerrar [post]
+func (h *JogoHandler) EncerrarPlacarAoVivo(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	placar, err := h.repo.EncerrarPlacarAoVivo(c.Request.Context(), id)
+	if err != nil {
+		respostaErroJogo(c, err, "encerrar jogo pelo placar ao vivo")
+		return
+	}
+
+	c.JSON(http.StatusOK, placar)
+}
+
+// GerarJogosGrupo godoc
+//
+//	@Summary		Gera os jogos de um grupo
+//	@Description	Cria as rodadas e os jogos de um grupo no formato todos contra todos (método do círculo). Em grupos com número ímpar de participantes, um participante folga em cada rodada.
+//	@Tags			Jogos
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Grupo"
+//	@Success		201	{array}		models.RodadaComJogos
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/grupos/{id}/jogos [post]
+func (h *JogoHandler) GerarJogosGrupo(c *gin.Context) {
+	grupoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do grupo inválido"})
+		return
+	}
+
+	rodadas, err := h.repo.GerarJogosGrupo(c.Request.Context(), grupoID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Grupo não encontrado"})
+			return
+		}
+		respostaErroJogo(c, err, "gerar jogos do grupo")
+		return
+	}
+
+	c.JSON(http.StatusCreated, rodadas)
+}
+
+// GerarJogosTorneio godoc
+//
+//	@Summary		Gera os jogos dos grupos de uma categoria
+//	@Description	Cria as rodadas e os jogos de todos os grupos de uma categoria do torneio no formato todos contra todos. Nenhum jogo é criado se algum grupo já possuir jogos.
+//	@Tags			Jogos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"ID do Torneio"
+//	@Param			input	body		models.GerarJogosInput	true	"Categoria dos grupos"
+//	@Success		201		{array}		models.RodadaComJogos
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/torneios/{id}/jogos [post]
+func (h *JogoHandler) GerarJogosTorneio(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+
+	var input models.GerarJogosInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	rodadas, err := h.repo.GerarJogosCategoria(c.Request.Context(), torneioID, input.CategoriaID)
+	if err != nil {
+		respostaErroJogo(c, err, "gerar jogos dos grupos do torneio")
+		return
+	}
+
+	c.JSON(http.StatusCreated, rodadas)
+}
+
+// AgendarJogos godoc
+//
+//	@Summary		Agenda os jogos pendentes de um torneio
+//	@Description	Distribui os jogos aguardando do torneio nas quadras disponíveis (quantidade_quadras), dentro do período do torneio e da janela diária, usando a duração estimada do esporte ajustada pelo formato de partida da categoria de cada jogo. Nenhum jogador joga duas partidas ao mesmo tempo e o descanso mínimo entre partidas é respeitado. O horário é gravado em data_hora e a quadra em localizacao.
+//	@Tags			Jogos
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"ID do Torneio"
+//	@Param			input	body		models.AgendaInput	true	"Janela diária e intervalos"
+//	@Success		200		{object}	models.ResultadoAgenda
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/torneios/{id}/agenda [post]
+func (h *JogoHandler) AgendarJogos(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+
+	var input models.AgendaInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	resultado, err := h.repo.AgendarJogos(c.Request.Context(), torneioID, input)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Torneio não encontrado"})
+			return
+		}
+		respostaErroJogo(c, err, "agendar jogos do torneio")
+		return
+	}
+
+	c.JSON(http.StatusOK, resultado)
+}
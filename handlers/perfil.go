@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"competitions/middleware"
+	"competitions/models"
+	"competitions/repository"
+	"competitions/validation"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PerfilHandler encapsula a lógica para as rotas /me, em que o usuário é sempre o
+// autenticado, identificado pela claim user_id do token.
+type PerfilHandler struct {
+	usuarios repository.UsuarioRepository
+	jogos    repository.JogoRepository
+}
+
+// NewPerfilHandler cria uma nova instância de PerfilHandler com os repositórios fornecidos.
+func NewPerfilHandler(usuarios repository.UsuarioRepository, jogos repository.JogoRepository) *PerfilHandler {
+	return &PerfilHandler{usuarios: usuarios, jogos: jogos}
+}
+
+// GetPerfil godoc
+//
+//	@Summary		Busca o perfil do usuário autenticado
+//	@Description	Retorna os dados cadastrais do usuário identificado pelo token.
+//	@Tags			Perfil
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	models.Usuario
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/me [get]
+func (h *PerfilHandler) GetPerfil(c *gin.Context) {
+	userID := middleware.UsuarioID(c)
+	usuario, err := h.usuarios.FindByID(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+		log.Printf("Erro ao buscar perfil do usuário %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar o perfil."})
+		return
+	}
+
+	c.JSON(http.StatusOK, usuario)
+}
+
+// UpdatePerfil godoc
+//
+//	@Summary		Atualiza o perfil do usuário autenticado
+//	@Description	Atualiza os dados cadastrais do usuário identificado pelo token. O tipo e a situação da conta não podem ser alterados por aqui.
+//	@Tags			Perfil
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			input	body		models.PerfilInput	true	"Dados cadastrais"
+//	@Success		200		{object}	models.Usuario
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/me [put]
+func (h *PerfilHandler) UpdatePerfil(c *gin.Context) {
+	var input models.PerfilInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	userID := middleware.UsuarioID(c)
+	usuario, err := h.usuarios.FindByID(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+		log.Printf("Erro ao buscar usuário %d para atualizar o perfil: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar o perfil."})
+		return
+	}
+
+	usuario.Nome = input.Nome
+	usuario.Username = input.Username
+	usuario.CPF = input.CPF
+	usuario.DataNascimento = input.DataNascimento
+	usuario.Email = input.Email
+	usuario.Telefone = input.Telefone
+	usuario.Instagram = input.Instagram
+
+	rowsAffected, err := h.usuarios.Update(c.Request.Context(), usuario)
+	if err != nil {
+		if respostaConflitoUsuario(c, err) {
+			return
+		}
+		log.Printf("Erro ao atualizar perfil do usuário %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao atualizar o perfil."})
+		return
+	}
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+		return
+	}
+
+	c.JSON(http.StatusOK, usuario)
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Altera a senha do usuário autenticado
+//	@Description	Altera a senha do usuário identificado pelo token, exigindo a senha atual para verificação.
+//	@Tags			Perfil
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			input	body		models.ChangePasswordInput	true	"Senha Antiga e Nova Senha"
+//	@Success		200		{object}	SuccessResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/me/change-password [put]
+func (h *PerfilHandler) ChangePassword(c *gin.Context) {
+	userID := uint(middleware.UsuarioID(c))
+
+	var input models.ChangePasswordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(&middleware.AppError{Code: http.StatusBadRequest, Message: "Corpo da requisição inválido.", Err: err})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.Error(&middleware.AppError{Code: http.StatusBadRequest, Message: "Dados inválidos.", Err: err})
+		return
+	}
+
+	// O método FindByID padrão omite a senha; a verificação usa o hash armazenado.
+	user, err := h.usuarios.FindByIDForAuth(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.Error(&middleware.AppError{Code: http.StatusNotFound, Message: "Usuário não encontrado.", Err: err})
+			return
+		}
+		c.Error(err)
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.OldPassword)); err != nil {
+		c.Error(&middleware.AppError{Code: http.StatusUnauthorized, Message: "Senha antiga incorreta.", Err: err})
+		return
+	}
+
+	if err := atualizarSenha(c, h.usuarios, userID, input.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Senha atualizada com sucesso."})
+}
+
+// GetEsportes godoc
+//
+//	@Summary		Lista os esportes do usuário autenticado
+//	@Description	Retorna os esportes associados ao jogador do usuário identificado pelo token.
+//	@Tags			Perfil
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		models.Esporte
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/me/esportes [get]
+func (h *PerfilHandler) GetEsportes(c *gin.Context) {
+	userID := middleware.UsuarioID(c)
+	esportes, err := h.usuarios.GetEsportesByUsuario(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrJogadorNaoEncontrado) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Erro ao buscar esportes do usuário %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar os esportes."})
+		return
+	}
+
+	c.JSON(http.StatusOK, esportes)
+}
+
+// AssociateEsporte godoc
+//
+//	@Summary		Associa o usuário autenticado a esportes
+//	@Description	Associa o jogador do usuário identificado pelo token a um ou mais esportes.
+//	@Tags			Perfil
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			input	body		models.EsporteAssociationInput	true	"IDs dos Esportes para Associar"
+//	@Success		201		{object}	SuccessResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/me/esportes [post]
+func (h *PerfilHandler) AssociateEsporte(c *gin.Context) {
+	var input models.EsporteAssociationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	userID := middleware.UsuarioID(c)
+	if err := h.usuarios.AssociateEsporte(c.Request.Context(), userID, input.EsporteIDs); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrJogadorNaoEncontrado):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrEsporteInvalido):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Printf("Erro ao associar esporte ao usuário %d: %v", userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao processar a associação."})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Jogador(es) associado(s) ao(s) esporte(s) com sucesso."})
+}
+
+// GetInscricoes godoc
+//
+//	@Summary		Lista as inscrições do usuário autenticado
+//	@Description	Retorna as inscrições do jogador do usuário identificado pelo token em torneios, individuais ou com as duplas de que ele faz parte, dos torneios mais recentes aos mais antigos.
+//	@Tags			Perfil
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		models.InscricaoUsuario
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/me/inscricoes [get]
+func (h *PerfilHandler) GetInscricoes(c *gin.Context) {
+	userID := middleware.UsuarioID(c)
+	inscricoes, err := h.usuarios.GetInscricoesByUsuario(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrJogadorNaoEncontrado) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Erro ao buscar inscrições do usuário %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar as inscrições."})
+		return
+	}
+
+	c.JSON(http.StatusOK, inscricoes)
+}
+
+// GetJogos godoc
+//
+//	@Summary		Lista os jogos do usuário autenticado
+//	@Description	Retorna os jogos disputados pelo jogador do usuário identificado pelo token, individualmente ou em dupla, com filtros opcionais por torneio e situação.
+//	@Tags			Perfil
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id_torneio	query		int		false	"ID do Torneio"
+//	@Param			situacao	query		string	false	"Situação do Jogo (aguardando, em andamento, encerrado)"
+//	@Success		200			{array}		models.Jogo
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/me/jogos [get]
+func (h *PerfilHandler) GetJogos(c *gin.Context) {
+	filtro := models.FiltroJogos{UsuarioID: middleware.UsuarioID(c), Situacao: c.Query("situacao")}
+	if valor := c.Query("id_torneio"); valor != "" {
+		id, err := strconv.Atoi(valor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro id_torneio inválido"})
+			return
+		}
+		filtro.TorneioID = id
+	}
+
+	jogos, err := h.jogos.FindAll(c.Request.Context(), filtro)
+	if err != nil {
+		log.Printf("Erro ao buscar jogos do usuário %d: %v", filtro.UsuarioID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar os jogos."})
+		return
+	}
+
+	c.JSON(http.StatusOK, jogos)
+}
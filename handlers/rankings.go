@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"competitions/models"
+	"competitions/repository"
+	"competitions/validation"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RankingHandler encapsula a lógica para as rotas de ranking de jogadores.
+type RankingHandler struct {
+	repo repository.RankingRepository
+}
+
+// NewRankingHandler cria uma nova instância de RankingHandler com o repositório fornecido.
+func NewRankingHandler(repo repository.RankingRepository) *RankingHandler {
+	return &RankingHandler{repo: repo}
+}
+
+// GetRanking godoc
+//
+//	@Summary		Ranking de jogadores de um esporte
+//	@Description	Retorna o ranking paginado dos jogadores de um esporte e modalidade, ordenado pelo rating, com a posição de cada jogador e o movimento em relação ao início do período. Os filtros de estado e cidade consideram os clubes dos quais o jogador faz parte.
+//	@Tags			Rankings
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id_esporte		query		int		true	"ID do Esporte"
+//	@Param			tipo_modalidade	query		string	false	"Modalidade (simples ou duplas, padrão: simples)"
+//	@Param			sexo			query		string	false	"Sexo (M ou F)"
+//	@Param			idade_min		query		int		false	"Idade mínima"
+//	@Param			idade_max		query		int		false	"Idade máxima"
+//	@Param			id_estado		query		int		false	"ID do Estado"
+//	@Param			id_cidade		query		int		false	"ID da Cidade"
+//	@Param			id_clube		query		int		false	"ID do Clube"
+//	@Param			periodo_dias	query		int		false	"Período, em dias, usado no cálculo do movimento (padrão: 30)"
+//	@Param			page			query		int		false	"Número da página (padrão: 1)"
+//	@Param			limit			query		int		false	"Itens por página (padrão: 20, máximo: 100)"
+//	@Success		200				{object}	models.Ranking
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/rankings [get]
+func (h *RankingHandler) GetRanking(c *gin.Context) {
+	var filtro models.FiltroRanking
+	if err := c.ShouldBindQuery(&filtro); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := filtro.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	ranking, err := h.repo.FindRanking(c.Request.Context(), filtro)
+	if err != nil {
+		log.Printf("Erro ao buscar ranking: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar o ranking."})
+		return
+	}
+
+	c.JSON(http.StatusOK, ranking)
+}
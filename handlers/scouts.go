@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"competitions/repository"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScoutHandler encapsula a lógica para as rotas administrativas de estatísticas dos jogadores.
+type ScoutHandler struct {
+	repo repository.ScoutRepository
+}
+
+// NewScoutHandler cria uma nova instância de ScoutHandler com o repositório fornecido.
+func NewScoutHandler(repo repository.ScoutRepository) *ScoutHandler {
+	return &ScoutHandler{repo: repo}
+}
+
+// RecalcularScouts godoc
+//
+//	@Summary		Recalcula as estatísticas dos jogadores
+//	@Description	Reprocessa todos os jogos encerrados em ordem cronológica e recalcula vitórias, derrotas, títulos, ratings e o histórico de ratings de todos os jogadores. Por padrão é uma simulação: apenas retorna as diferenças encontradas, sem gravar nada; com aplicar=true, grava as correções (como a opção -aplicar do comando recalcular-scouts). Restrito a administradores.
+//	@Tags			Administração
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			aplicar	query		bool	false	"Grava as estatísticas recalculadas (padrão: false, apenas relata as diferenças)"
+//	@Success		200		{object}	models.RelatorioRecalculo
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/admin/scouts/recalcular [post]
+func (h *ScoutHandler) RecalcularScouts(c *gin.Context) {
+	aplicar := false
+	if valor := c.Query("aplicar"); valor != "" {
+		var err error
+		if aplicar, err = strconv.ParseBool(valor); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro aplicar inválido"})
+			return
+		}
+	}
+
+	relatorio, err := h.repo.Recalcular(c.Request.Context(), !aplicar)
+	if err != nil {
+		log.Printf("Erro ao recalcular scouts: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao recalcular as estatísticas."})
+		return
+	}
+
+	c.JSON(http.StatusOK, relatorio)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"competitions/middleware"
 	"competitions/models"
 	"competitions/repository"
 	"competitions/validation"
@@ -30,7 +31,7 @@ func NewTorneioHandler(repo repository.TorneioRepository) *TorneioHandler {
 // CreateTorneio godoc
 //
 //	@Summary		Cria um novo torneio
-//	@Description	Cria um novo torneio no sistema.
+//	@Description	Cria um novo torneio no sistema. O usuário autenticado é registrado como criador e organizador do torneio.
 //	@Tags			Torneios
 //	@Accept			json
 //	@Produce		json
@@ -71,7 +72,7 @@ func (h *TorneioHandler) CreateTorneio(c *gin.Context) {
 		return
 	}
 
-	torneio, err := h.repo.Create(c.Request.Context(), input)
+	torneio, err := h.repo.Create(c.Request.Context(), input, middleware.UsuarioID(c))
 	if err != nil {
 		log.Printf("Erro ao criar torneio: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro interno ao criar o torneio."})
@@ -221,7 +222,7 @@ func (h *TorneioHandler) UpdateTorneio(c *gin.Context) {
 // DeleteTorneio godoc
 //
 //	@Summary		Deleta um torneio
-//	@Description	Deleta um torneio do sistema com base no ID fornecido.
+//	@Description	Deleta um torneio do sistema com base no ID fornecido. Apenas o criador do torneio e administradores podem deletá-lo; coorganizadores não.
 //	@Tags			Torneios
 //	@Accept			json
 //	@Produce		json
@@ -229,6 +230,7 @@ func (h *TorneioHandler) UpdateTorneio(c *gin.Context) {
 //	@Param			id	path		int	true	"ID do Torneio"
 //	@Success		200	{object}	SuccessResponse
 //	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
 //	@Failure		404	{object}	ErrorResponse
 //	@Failure		500	{object}	ErrorResponse
 //
@@ -390,3 +392,267 @@ func (h *TorneioHandler) ListarInscricoes(c *gin.Context) {
 
 	c.JSON(http.StatusOK, inscricoes)
 }
+
+// GetFormatosPartida godoc
+//
+//	@Summary		Lista os formatos de partida de um torneio
+//	@Description	Retorna o formato geral das partidas do torneio e os formatos específicos de cada categoria, com a duração estimada de uma partida em cada formato. Opções omitidas seguem a regra oficial do esporte.
+//	@Tags			Torneios
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Torneio"
+//	@Success		200	{array}		models.FormatoPartida
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/torneios/{id}/formatos [get]
+func (h *TorneioHandler) GetFormatosPartida(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+
+	formatos, err := h.repo.FindFormatosPartida(c.Request.Context(), torneioID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Torneio não encontrado"})
+			return
+		}
+		log.Printf("Erro ao buscar formatos de partida do torneio %d: %v", torneioID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar os formatos de partida."})
+		return
+	}
+
+	c.JSON(http.StatusOK, formatos)
+}
+
+// SalvarFormatoPartida godoc
+//
+//	@Summary		Define o formato de partida de um torneio ou categoria
+//	@Description	Cria ou substitui o formato das partidas do torneio (sem id_categoria) ou de uma categoria: melhor de 1, 3 ou 5 sets, games por set, pontos por game, sem vantagem (no-ad), tiebreak no empate em games e super tiebreak no lugar do último set. O formato é usado na validação dos placares e na estimativa de duração do agendamento.
+//	@Tags			Torneios
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int							true	"ID do Torneio"
+//	@Param			formato	body		models.FormatoPartidaInput	true	"Formato das partidas"
+//	@Success		200		{object}	models.FormatoPartida
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/torneios/{id}/formatos [put]
+func (h *TorneioHandler) SalvarFormatoPartida(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+
+	var input models.FormatoPartidaInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	formato, err := h.repo.SalvarFormatoPartida(c.Request.Context(), torneioID, input)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido fornecido. O torneio ou a categoria especificada não existe."})
+			return
+		}
+		log.Printf("Erro ao salvar formato de partida do torneio %d: %v", torneioID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao salvar o formato de partida."})
+		return
+	}
+
+	c.JSON(http.StatusOK, formato)
+}
+
+// DeleteFormatoPartida godoc
+//
+//	@Summary		Remove o formato de partida de um torneio ou categoria
+//	@Description	Remove o formato geral do torneio (sem id_categoria) ou o de uma categoria. As partidas voltam a seguir o formato geral do torneio ou a regra oficial do esporte.
+//	@Tags			Torneios
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int	true	"ID do Torneio"
+//	@Param			id_categoria	query		int	false	"ID da Categoria"
+//	@Success		200				{object}	SuccessResponse
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/torneios/{id}/formatos [delete]
+func (h *TorneioHandler) DeleteFormatoPartida(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+
+	var categoriaID *int
+	if valor := c.Query("id_categoria"); valor != "" {
+		id, err := strconv.Atoi(valor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro id_categoria inválido"})
+			return
+		}
+		categoriaID = &id
+	}
+
+	rowsAffected, err := h.repo.DeleteFormatoPartida(c.Request.Context(), torneioID, categoriaID)
+	if err != nil {
+		log.Printf("Erro ao remover formato de partida do torneio %d: %v", torneioID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao remover o formato de partida."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Formato de partida não encontrado"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Formato de partida removido com sucesso"})
+}
+
+// SomenteOrganizadores restringe a rota, identificada pelo ID do torneio, ao criador do
+// torneio, aos seus coorganizadores e aos administradores.
+func (h *TorneioHandler) SomenteOrganizadores(acao middleware.Acao) gin.HandlerFunc {
+	return middleware.RequerOrganizador(acao, h.repo.EhOrganizador)
+}
+
+// SomenteCriador restringe a rota, identificada pelo ID do torneio, ao criador do torneio e
+// aos administradores.
+func (h *TorneioHandler) SomenteCriador(acao middleware.Acao) gin.HandlerFunc {
+	return middleware.RequerOrganizador(acao, h.repo.EhCriador)
+}
+
+// GetOrganizadores godoc
+//
+//	@Summary		Lista os organizadores de um torneio
+//	@Description	Retorna o criador do torneio, seguido dos coorganizadores. Organizadores podem alterar o torneio, seus grupos, jogos, chaves, agenda e resultados.
+//	@Tags			Torneios
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Torneio"
+//	@Success		200	{array}		models.OrganizadorTorneio
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/torneios/{id}/organizadores [get]
+func (h *TorneioHandler) GetOrganizadores(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+
+	organizadores, err := h.repo.FindOrganizadores(c.Request.Context(), torneioID)
+	if err != nil {
+		log.Printf("Erro ao buscar organizadores do torneio %d: %v", torneioID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar os organizadores."})
+		return
+	}
+
+	c.JSON(http.StatusOK, organizadores)
+}
+
+// AdicionarOrganizador godoc
+//
+//	@Summary		Adiciona um coorganizador ao torneio
+//	@Description	Concede a um usuário a organização do torneio. Apenas o criador do torneio e administradores podem adicionar coorganizadores.
+//	@Tags			Torneios
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"ID do Torneio"
+//	@Param			input	body		models.OrganizadorInput	true	"Usuário a ser adicionado"
+//	@Success		201		{object}	SuccessResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/torneios/{id}/organizadores [post]
+func (h *TorneioHandler) AdicionarOrganizador(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+
+	var input models.OrganizadorInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Dados inválidos.",
+			"errors":  validation.TranslateError(err),
+		})
+		return
+	}
+
+	if err := h.repo.AdicionarOrganizador(c.Request.Context(), torneioID, input.UsuarioID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+		log.Printf("Erro ao adicionar organizador %d ao torneio %d: %v", input.UsuarioID, torneioID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao adicionar o organizador."})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Organizador adicionado com sucesso"})
+}
+
+// RemoverOrganizador godoc
+//
+//	@Summary		Remove um coorganizador do torneio
+//	@Description	Revoga a organização do torneio de um coorganizador. O criador do torneio não pode ser removido. Apenas o criador do torneio e administradores podem remover coorganizadores.
+//	@Tags			Torneios
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int	true	"ID do Torneio"
+//	@Param			id_usuario	path		int	true	"ID do Usuário"
+//	@Success		200			{object}	SuccessResponse
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/torneios/{id}/organizadores/{id_usuario} [delete]
+func (h *TorneioHandler) RemoverOrganizador(c *gin.Context) {
+	torneioID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do torneio inválido"})
+		return
+	}
+	usuarioID, err := strconv.Atoi(c.Param("id_usuario"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do usuário inválido"})
+		return
+	}
+
+	rowsAffected, err := h.repo.RemoverOrganizador(c.Request.Context(), torneioID, usuarioID)
+	if err != nil {
+		log.Printf("Erro ao remover organizador %d do torneio %d: %v", usuarioID, torneioID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao remover o organizador."})
+		return
+	}
+
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Organizador não encontrado"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Organizador removido com sucesso"})
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"competitions/models"
+	"competitions/repository"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// intervaloTransmissao é o intervalo entre as leituras de novos eventos de uma transmissão.
+	intervaloTransmissao = time.Second
+	// intervaloKeepAlive é o intervalo máximo sem escrita na conexão, evitando que proxies a encerrem.
+	intervaloKeepAlive = 15 * time.Second
+	// reconexaoTransmissao é o tempo, em milissegundos, que o navegador aguarda antes de reconectar.
+	reconexaoTransmissao = 3000
+)
+
+// TransmissaoHandler encapsula a lógica para a transmissão ao vivo dos torneios.
+type TransmissaoHandler struct {
+	repo repository.TransmissaoRepository
+}
+
+// NewTransmissaoHandler cria uma nova instância de TransmissaoHandler com o repositório fornecido.
+func NewTransmissaoHandler(repo repository.TransmissaoRepository) *TransmissaoHandler {
+	return &TransmissaoHandler{repo: repo}
+}
+
+// TransmitirTorneio godoc
+//
+//	@Summary		Transmissão ao vivo de um torneio (Server-Sent Events)
+//	@Description	Mantém a conexão aberta e envia, como Server-Sent Events, as alterações de placar ao vivo ('placar'), os resultados gravados ('resultado'), as mudanças de situação dos jogos ('situacao') e as atribuições de quadra e horário ('quadra') do torneio. Os eventos podem ser restritos a uma quadra e/ou a um jogo. Cada evento traz um ID; ao reconectar, o navegador envia o cabeçalho Last-Event-ID e a transmissão continua do evento seguinte. Sem ele (ou o parâmetro ultimo_evento), apenas eventos novos são enviados, e o estado atual deve ser lido pelas rotas de jogos.
+//	@Tags			Torneios
+//	@Produce		text/event-stream
+//	@Param			id				path		int		true	"ID do Torneio"
+//	@Param			quadra			query		string	false	"Quadra (ex.: 'Quadra 1')"
+//	@Param			id_jogo			query		int		false	"ID do Jogo"
+//	@Param			ultimo_evento	query		string	false	"ID do último evento recebido (alternativa ao cabeçalho Last-Event-ID)"
+//	@Param			Last-Event-ID	header		string	false	"ID do último evento recebido"
+//	@Success		200				{object}	models.EventoTransmissao
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/torneios/{id}/transmissao [get]
+func (h *TransmissaoHandler) TransmitirTorneio(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	filtro := models.FiltroTransmissao{TorneioID: id, Quadra: c.Query("quadra")}
+	if v := c.Query("id_jogo"); v != "" {
+		if filtro.JogoID, err = strconv.Atoi(v); err != nil || filtro.JogoID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "id_jogo inválido"})
+			return
+		}
+	}
+
+	ultimo := c.GetHeader("Last-Event-ID")
+	if ultimo == "" {
+		ultimo = c.Query("ultimo_evento")
+	}
+	ctx := c.Request.Context()
+	if ultimo != "" {
+		if filtro.Apos, err = models.ParsePosicaoTransmissao(ultimo); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	// A posição atual também confirma que o torneio existe.
+	atual, err := h.repo.PosicaoAtual(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Torneio não encontrado"})
+			return
+		}
+		log.Printf("Erro ao iniciar transmissão do torneio %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao iniciar a transmissão."})
+		return
+	}
+	if ultimo == "" {
+		filtro.Apos = atual
+	}
+
+	c.Header("Content-Type", sse.ContentType)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Desativa o buffer de proxies como o nginx
+	c.Status(http.StatusOK)
+	_ = sse.Encode(c.Writer, sse.Event{Event: "conectado", Retry: reconexaoTransmissao, Data: gin.H{"id_torneio": id}})
+	c.Writer.Flush()
+
+	leitura := time.NewTicker(intervaloTransmissao)
+	defer leitura.Stop()
+	ultimaEscrita := time.Now()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-leitura.C:
+		}
+
+		eventos, err := h.repo.FindEventos(ctx, filtro)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Erro na transmissão do torneio %d: %v", id, err)
+			}
+			return false
+		}
+		for _, e := range eventos {
+			if err := sse.Encode(w, sse.Event{Id: e.ID, Event: e.Tipo, Data: e}); err != nil {
+				return false
+			}
+			filtro.Apos = e.Posicao
+		}
+
+		if len(eventos) > 0 {
+			ultimaEscrita = time.Now()
+		} else if time.Since(ultimaEscrita) >= intervaloKeepAlive {
+			// Linhas iniciadas por ':' são comentários e são ignoradas pelo cliente.
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return false
+			}
+			ultimaEscrita = time.Now()
+		}
+		return true
+	})
+}
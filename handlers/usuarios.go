@@ -9,10 +9,12 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
-	jwt "github.com/appleboy/gin-jwt/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -26,6 +28,31 @@ func NewUsuarioHandler(repo repository.UsuarioRepository) *UsuarioHandler {
 	return &UsuarioHandler{repo: repo}
 }
 
+// camposUnicosUsuario associa o trecho do nome das restrições de unicidade de usuarios (e de
+// jogadores, preenchida pela trigger de cadastro) ao campo e à mensagem de conflito.
+var camposUnicosUsuario = []struct{ campo, mensagem string }{
+	{"email", "Já existe um usuário com este e-mail."},
+	{"username", "Já existe um usuário com este username."},
+	{"cpf", "Já existe um usuário com este CPF."},
+}
+
+// respostaConflitoUsuario responde 409, indicando o campo em conflito, se o erro for a
+// violação de unicidade de e-mail, username ou CPF. Retorna falso para os demais erros.
+func respostaConflitoUsuario(c *gin.Context, err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" { // unique_violation
+		return false
+	}
+	for _, u := range camposUnicosUsuario {
+		if strings.Contains(pgErr.ConstraintName, u.campo) {
+			c.JSON(http.StatusConflict, gin.H{"error": u.mensagem, "campo": u.campo})
+			return true
+		}
+	}
+	c.JSON(http.StatusConflict, gin.H{"error": "Já existe um usuário com estes dados."})
+	return true
+}
+
 // GetUsuarios godoc
 //
 //	@Summary		Lista todos os usuários
@@ -155,6 +182,9 @@ func (h *UsuarioHandler) CreateUsuario(c *gin.Context) {
 
 	err = h.repo.Create(c.Request.Context(), &usuario)
 	if err != nil {
+		if respostaConflitoUsuario(c, err) {
+			return
+		}
 		log.Printf("Erro ao criar usuário: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao criar o usuário."})
 		return
@@ -236,6 +266,9 @@ func (h *UsuarioHandler) UpdateUsuario(c *gin.Context) {
 	// 3. Chamar o repositório para atualizar o usuário
 	rowsAffected, err := h.repo.Update(c.Request.Context(), existingUser)
 	if err != nil {
+		if respostaConflitoUsuario(c, err) {
+			return
+		}
 		log.Printf("Erro ao atualizar usuário %d: %v", idInt, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao atualizar o usuário."})
 		return
@@ -291,110 +324,62 @@ func (h *UsuarioHandler) DeleteUsuario(c *gin.Context) {
 
 // ChangePassword godoc
 //
-//	@Summary		Altera a senha do usuário
-//	@Description	Altera a senha de um usuário específico, exigindo a senha antiga para verificação.
+//	@Summary		Redefine a senha de um usuário
+//	@Description	Define uma nova senha para o usuário informado, sem exigir a senha atual. Rota administrativa; o próprio usuário altera a sua senha em /me/change-password.
 //	@Tags			Usuários
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			id		path		int							true	"ID do Usuário"
-//	@Param			input	body		models.ChangePasswordInput	true	"Senha Antiga e Nova Senha"
+//	@Param			input	body		models.RedefinirSenhaInput	true	"Nova Senha"
 //	@Success		200		{object}	SuccessResponse
 //	@Failure		400		{object}	ErrorResponse
-//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
 //	@Failure		404		{object}	ErrorResponse
 //	@Failure		500		{object}	ErrorResponse
-//
-// swagger:route POST /usuarios/{id}/change-password usuarios ChangePassword
-// ChangePassword altera a senha de um usuário específico, exigindo a senha antiga para verificação.
-// swagger:response ChangePassword
-// A resposta contém uma mensagem de sucesso indicando que a senha foi alterada com sucesso.
-// Se o ID fornecido for inválido, uma mensagem de erro será retornada com o status HTTP 400 (Bad Request).
-// Se a senha antiga fornecida não corresponder à senha atual, uma mensagem de erro será retornada com o status HTTP 401 (Unauthorized).
-// Se o usuário não for encontrado, uma mensagem de erro será retornada com o status HTTP404 (Not Found).
-// Se ocorrer um erro ao alterar a senha, uma mensagem de erro será retornada com o status HTTP 500 (Internal Server Error).
-// ChangePassword altera a senha de um usuário específico, exigindo a senha antiga para verificação.
-// Esta rota deve ser protegida por autenticação/autorização de usuário.
-// A função recebe o ID do usuário na URL e os dados da nova senha no corpo da requisição.
-// Ela verifica se o ID do usuário na URL corresponde ao ID do usuário no token JWT, garantindo que o usuário só possa alterar
+//	@Router			/usuarios/{id}/change-password [put]
 func (h *UsuarioHandler) ChangePassword(c *gin.Context) {
-	// O ID do usuário na URL (:id) deve corresponder ao ID do usuário no token.
-	// Isso impede que um usuário tente alterar a senha de outro.
-	idParam := c.Param("id")
-	idFromURL, err := strconv.ParseUint(idParam, 10, 32)
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		c.Error(&middleware.AppError{Code: http.StatusBadRequest, Message: "ID de usuário inválido na URL.", Err: err})
 		return
 	}
 
-	// Extrai as claims do token JWT. O middleware já garantiu que o token é válido.
-	claims := jwt.ExtractClaims(c)
-	// O ID do usuário é armazenado como float64 após a decodificação do JSON do token.
-	userIDClaim, ok := claims["user_id"].(float64)
-	if !ok {
-		c.Error(&middleware.AppError{Code: http.StatusUnauthorized, Message: "ID de usuário inválido ou ausente no token.", Err: errors.New("invalid token claims")})
-		return
-	}
-	// Converte o ID para o tipo usado no modelo (uint).
-	userID := uint(userIDClaim)
-
-	var input models.ChangePasswordInput
+	var input models.RedefinirSenhaInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.Error(&middleware.AppError{Code: http.StatusBadRequest, Message: "Corpo da requisição inválido.", Err: err})
 		return
 	}
 
-	// Verificação de segurança crucial
-	if uint(idFromURL) != userID {
-		c.Error(&middleware.AppError{Code: http.StatusForbidden, Message: "Você não tem permissão para alterar a senha deste usuário.", Err: errors.New("permission denied")})
-		return
-	}
-
 	if err := input.Validate(); err != nil {
 		c.Error(&middleware.AppError{Code: http.StatusBadRequest, Message: "Dados inválidos.", Err: err})
 		return
 	}
 
-	// 1. Buscar a senha hash atual do banco de dados
-	// Usamos um método de repositório específico que busca a senha.
-	// O método FindByID padrão omite a senha por segurança.
-	user, err := h.repo.FindByIDForAuth(c.Request.Context(), userID)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			c.Error(&middleware.AppError{Code: http.StatusNotFound, Message: "Usuário não encontrado.", Err: err})
-			return
-		}
-		c.Error(err) // Deixa o middleware tratar
+	if err := atualizarSenha(c, h.repo, uint(userID), input.NewPassword); err != nil {
+		c.Error(err)
 		return
 	}
 
-	// 2. Comparar a senha antiga fornecida com o hash armazenado
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.OldPassword))
-	if err != nil {
-		c.Error(&middleware.AppError{Code: http.StatusUnauthorized, Message: "Senha antiga incorreta.", Err: err})
-		return
-	}
+	c.JSON(http.StatusOK, gin.H{"message": "Senha atualizada com sucesso."})
+}
 
-	// 3. Gerar um novo hash para a nova senha
-	newHashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.NewPassword), bcrypt.DefaultCost)
+// atualizarSenha gera o hash da nova senha e a grava para o usuário. Retorna um AppError 404
+// se o usuário não existir.
+func atualizarSenha(c *gin.Context, repo repository.UsuarioRepository, userID uint, novaSenha string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(novaSenha), bcrypt.DefaultCost)
 	if err != nil {
-		c.Error(err)
-		return
+		return err
 	}
 
-	// 4. Atualizar a senha no banco de dados
-	rowsAffected, err := h.repo.UpdatePassword(c.Request.Context(), userID, string(newHashedPassword))
+	rowsAffected, err := repo.UpdatePassword(c.Request.Context(), userID, string(hash))
 	if err != nil {
-		c.Error(err)
-		return
+		return err
 	}
-
 	if rowsAffected == 0 {
-		c.Error(&middleware.AppError{Code: http.StatusNotFound, Message: "Usuário não encontrado para atualizar a senha.", Err: errors.New("user not found on update")})
-		return
+		return &middleware.AppError{Code: http.StatusNotFound, Message: "Usuário não encontrado para atualizar a senha.", Err: errors.New("user not found on update")}
 	}
-
-	c.JSON(http.StatusOK, gin.H{"message": "Senha atualizada com sucesso."})
+	return nil
 }
 
 // AssociateEsporte associa um jogador a um esporte
@@ -506,6 +491,109 @@ func (h *UsuarioHandler) GetEsportesByUsuario(c *gin.Context) {
 	c.JSON(http.StatusOK, esportes)
 }
 
+// GetRatingsByUsuario godoc
+//
+//	@Summary		Busca os ratings de um jogador
+//	@Description	Retorna o rating do jogador do usuário em cada esporte e modalidade (simples/duplas) em que ele já disputou jogos.
+//	@Tags			Usuários
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"ID do Usuário (Jogador)"
+//	@Success		200	{array}		models.RatingJogador
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/usuarios/{id}/ratings [get]
+func (h *UsuarioHandler) GetRatingsByUsuario(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuário inválido"})
+		return
+	}
+
+	ratings, err := h.repo.GetRatingsByUsuario(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrJogadorNaoEncontrado) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Jogador não encontrado para o usuário"})
+			return
+		}
+		log.Printf("Erro ao buscar ratings do usuário %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar os ratings."})
+		return
+	}
+
+	c.JSON(http.StatusOK, ratings)
+}
+
+// GetHistoricoRatingsByUsuario godoc
+//
+//	@Summary		Linha do tempo de rating de um jogador
+//	@Description	Retorna, em ordem cronológica, a variação de rating do jogador do usuário em cada jogo (antes, depois, delta, adversário e jogo). As datas são no formato AAAA-MM-DD e o intervalo inclui os dois dias informados.
+//	@Tags			Usuários
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int		true	"ID do Usuário (Jogador)"
+//	@Param			id_esporte		query		int		false	"ID do Esporte"
+//	@Param			tipo_modalidade	query		string	false	"Modalidade (simples ou duplas)"
+//	@Param			de				query		string	false	"Data inicial (AAAA-MM-DD)"
+//	@Param			ate				query		string	false	"Data final (AAAA-MM-DD)"
+//	@Success		200				{array}		models.HistoricoRating
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/usuarios/{id}/ratings/historico [get]
+func (h *UsuarioHandler) GetHistoricoRatingsByUsuario(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de usuário inválido"})
+		return
+	}
+
+	var filtro models.FiltroHistoricoRating
+	if valor := c.Query("id_esporte"); valor != "" {
+		if filtro.EsporteID, err = strconv.Atoi(valor); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro id_esporte inválido"})
+			return
+		}
+	}
+	switch filtro.TipoModalidade = c.Query("tipo_modalidade"); filtro.TipoModalidade {
+	case "", "simples", "duplas":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro tipo_modalidade inválido"})
+		return
+	}
+	if valor := c.Query("de"); valor != "" {
+		de, err := time.Parse("2006-01-02", valor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro de inválido. Use o formato AAAA-MM-DD"})
+			return
+		}
+		filtro.De = &de
+	}
+	if valor := c.Query("ate"); valor != "" {
+		ate, err := time.Parse("2006-01-02", valor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro ate inválido. Use o formato AAAA-MM-DD"})
+			return
+		}
+		ate = ate.AddDate(0, 0, 1) // Inclui o dia informado.
+		filtro.Ate = &ate
+	}
+
+	historico, err := h.repo.GetHistoricoRatingsByUsuario(c.Request.Context(), userID, filtro)
+	if err != nil {
+		if errors.Is(err, repository.ErrJogadorNaoEncontrado) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Jogador não encontrado para o usuário"})
+			return
+		}
+		log.Printf("Erro ao buscar histórico de ratings do usuário %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao buscar o histórico de ratings."})
+		return
+	}
+
+	c.JSON(http.StatusOK, historico)
+}
+
 // GetUsuariosByEsporte retorna os usuários associados a um esporte específico
 // godoc
 //
@@ -56,6 +56,14 @@ func main() {
 	esporteRepo := repository.NewEsporteRepository(config.DB)
 
 	grupoRepo := repository.NewGrupoRepository(config.DB)
+	jogoRepo := repository.NewJogoRepository(config.DB)
+	chaveamentoRepo := repository.NewChaveamentoRepository(config.DB)
+	duplaRepo := repository.NewDuplaRepository(config.DB)
+	scoutRepo := repository.NewScoutRepository(config.DB)
+	rankingRepo := repository.NewRankingRepository(config.DB)
+	circuitoRepo := repository.NewCircuitoRepository(config.DB)
+	clubeRepo := repository.NewClubeRepository(config.DB)
+	transmissaoRepo := repository.NewTransmissaoRepository(config.DB)
 
 	// 2. Instanciar Handlers, injetando os repositórios
 	authHandler := handlers.NewAuthHandler(userRepo)
@@ -63,6 +71,15 @@ func main() {
 	torneioHandler := handlers.NewTorneioHandler(torneioRepo)
 	esporteHandler := handlers.NewEsporteHandler(esporteRepo)
 	grupoHandler := handlers.NewGrupoHandler(grupoRepo) // Adicionado
+	jogoHandler := handlers.NewJogoHandler(jogoRepo)
+	chaveamentoHandler := handlers.NewChaveamentoHandler(chaveamentoRepo)
+	duplaHandler := handlers.NewDuplaHandler(duplaRepo)
+	scoutHandler := handlers.NewScoutHandler(scoutRepo)
+	rankingHandler := handlers.NewRankingHandler(rankingRepo)
+	circuitoHandler := handlers.NewCircuitoHandler(circuitoRepo)
+	clubeHandler := handlers.NewClubeHandler(clubeRepo)
+	transmissaoHandler := handlers.NewTransmissaoHandler(transmissaoRepo)
+	perfilHandler := handlers.NewPerfilHandler(userRepo, jogoRepo)
 
 	router := gin.Default()
 
@@ -75,7 +92,7 @@ func main() {
 	// Adiciona o middleware de tratamento de erros
 	router.Use(middleware.ErrorHandler())
 	// Registra as rotas, passando os handlers e repositórios necessários
-	routes.RegisterRoutes(router, userHandler, torneioHandler, esporteHandler, grupoHandler, authHandler, jwtSecret)
+	routes.RegisterRoutes(router, userHandler, torneioHandler, esporteHandler, grupoHandler, jogoHandler, chaveamentoHandler, duplaHandler, scoutHandler, rankingHandler, circuitoHandler, clubeHandler, transmissaoHandler, perfilHandler, authHandler, jwtSecret)
 
 	//Create and configure the MCP server
 	//Provide essential details for the MCP client.
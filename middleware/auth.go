@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"competitions/models"
+	"context"
 	"log"
+	"net/http"
 	"time"
 
 	jwt "github.com/appleboy/gin-jwt/v2"
@@ -11,10 +13,21 @@ import (
 
 var identityKey = "user_id"
 
+const (
+	// claimVersaoToken é a claim com a versão dos tokens do usuário no momento da emissão.
+	claimVersaoToken = "versao_token"
+	// chaveTokenRevogado marca no contexto que o token foi rejeitado por estar revogado.
+	chaveTokenRevogado = "token_revogado"
+	// MensagemTokenRevogado é o corpo das respostas 401 para tokens revogados.
+	MensagemTokenRevogado = "Sessão encerrada ou token revogado. Faça login novamente."
+)
+
 // Authenticator define a interface que a lógica de login deve satisfazer.
 // Isso quebra o ciclo de importação entre os pacotes middleware e handlers.
 type Authenticator interface {
 	Login(c *gin.Context) (interface{}, error)
+	// TokenVigente informa se os tokens do usuário com a versão informada ainda são válidos.
+	TokenVigente(ctx context.Context, userID uint, versao int) bool
 }
 
 // AuthMiddleware cria e configura o middleware de autenticação JWT.
@@ -26,22 +39,30 @@ func AuthMiddleware(secretKey string, auth Authenticator) *jwt.GinJWTMiddleware
 		Realm:         "competitions-api",
 		Key:           []byte(secretKey),
 		Timeout:       time.Hour * 24,
-		MaxRefresh:    time.Hour * 24,
-		IdentityKey:   identityKey,
+		// O gin-jwt permite renovar o token em /auth/refresh enquanto orig_iat + MaxRefresh não
+		// passar, e orig_iat é a emissão do token atual (cada renovação reinicia o prazo). Com
+		// 7 dias, um token expirado ainda pode ser renovado por até 6 dias após a expiração.
+		MaxRefresh:  time.Hour * 24 * 7,
+		IdentityKey: identityKey,
 		// PayloadFunc é usado pelo handler de login do middleware para criar o token.
 		// Como seu login é customizado, esta função serve como um padrão caso você
 		// decida usar o gerador de token da biblioteca em outro lugar.
 		PayloadFunc: func(data interface{}) jwt.MapClaims {
 			if v, ok := data.(*models.Usuario); ok {
 				return jwt.MapClaims{
-					identityKey: v.ID,
-					"type":      v.Tipo,
+					identityKey:      v.ID,
+					"type":           v.Tipo,
+					claimVersaoToken: v.VersaoToken,
 				}
 			}
 			return jwt.MapClaims{}
 		},
 		// IdentityHandler extrai a identidade do usuário a partir do token.
-		// O valor retornado é passado para a função Authorizator.
+		// O valor retornado é passado para a função Authorizator. Tokens revogados (versão
+		// diferente da atual do usuário ou conta desativada) não têm identidade.
+		// A verificação da revogação lê a versão atual do usuário no banco a cada requisição
+		// autenticada (uma consulta pela chave primária de usuarios), sem cache, para que
+		// logout, troca de senha e desativação valham imediatamente em todas as instâncias.
 		IdentityHandler: func(c *gin.Context) interface{} {
 			claims := jwt.ExtractClaims(c) // Extrai as claims do token
 			// Reconstrói um objeto models.Usuario a partir das claims
@@ -53,16 +74,28 @@ func AuthMiddleware(secretKey string, auth Authenticator) *jwt.GinJWTMiddleware
 			if !ok {
 				return nil // Ou trate o erro apropriadamente
 			}
+			if !tokenVigente(c, auth, claims) {
+				c.Set(chaveTokenRevogado, true)
+				return nil
+			}
 			return &models.Usuario{ID: uint(userID), Tipo: userType} // Retorna um *models.Usuario
 		},
 		// Authorizator é chamado em cada requisição para verificar se o usuário
 		// (identificado pelo token) tem permissão para acessar.
+		// As permissões de cada rota são verificadas por RequerPermissao; aqui apenas se
+		// exige que o token pertença a um tipo de usuário conhecido.
 		Authorizator: func(data interface{}, c *gin.Context) bool {
-			_, ok := data.(*models.Usuario) // Verifica se os dados são um *models.Usuario
-			return ok                       // Se for um Usuario, a autorização é bem-sucedida (por enquanto)
+			u, ok := data.(*models.Usuario)
+			return ok && Permite(u.Tipo, Consultar)
 		},
-		// Unauthorized é a resposta enviada quando a autenticação falha.
+		// Unauthorized é a resposta enviada quando a autenticação ou a autorização falha.
 		Unauthorized: func(c *gin.Context, code int, message string) {
+			switch {
+			case c.GetBool(chaveTokenRevogado):
+				code, message = http.StatusUnauthorized, MensagemTokenRevogado
+			case code == http.StatusForbidden:
+				message = MensagemPermissaoNegada
+			}
 			c.JSON(code, gin.H{"error": message})
 		},
 		TokenLookup: "header: Authorization",
@@ -74,3 +107,35 @@ func AuthMiddleware(secretKey string, auth Authenticator) *jwt.GinJWTMiddleware
 
 	return authMiddleware
 }
+
+// tokenVigente informa se o token das claims não foi revogado. Tokens sem a versão, emitidos
+// antes da revogação por versão, são considerados revogados.
+func tokenVigente(c *gin.Context, auth Authenticator, claims map[string]any) bool {
+	userID, ok := claims[identityKey].(float64)
+	if !ok {
+		return false
+	}
+	versao, ok := claims[claimVersaoToken].(float64)
+	if !ok {
+		return false
+	}
+	return auth.TokenVigente(c.Request.Context(), uint(userID), int(versao))
+}
+
+// RequerTokenRenovavel protege a rota de renovação do token: o RefreshHandler do gin-jwt
+// aceita tokens expirados dentro do prazo de MaxRefresh, mas não passa pelo IdentityHandler,
+// por isso a revogação é verificada aqui.
+func RequerTokenRenovavel(mw *jwt.GinJWTMiddleware, auth Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := mw.CheckIfTokenExpire(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": mw.HTTPStatusMessageFunc(err, c)})
+			return
+		}
+		if !tokenVigente(c, auth, claims) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": MensagemTokenRevogado})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"competitions/models"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// Acao é uma ação protegida da API. As rotas declaram a ação que executam e cada tipo de
+// usuário possui um conjunto de ações permitidas.
+type Acao string
+
+// Ações protegidas da API.
+const (
+	Consultar           Acao = "consultar"            // Ler torneios, jogos, grupos, rankings, circuitos...
+	Inscrever           Acao = "inscrever"            // Inscrever jogadores em torneios e gerenciar duplas
+	GerenciarTorneios   Acao = "gerenciar_torneios"   // Criar e alterar torneios, grupos, jogos, chaves, agenda e circuitos
+	RegistrarResultados Acao = "registrar_resultados" // Registrar resultados, sets e o placar ao vivo
+	GerenciarClubes     Acao = "gerenciar_clubes"     // Criar e alterar clubes e seus membros
+	GerenciarUsuarios   Acao = "gerenciar_usuarios"   // Criar, listar, alterar e remover usuários
+	ConfigurarEsportes  Acao = "configurar_esportes"  // Alterar a configuração dos esportes (ex.: rating)
+	Administrar         Acao = "administrar"          // Operações administrativas (ex.: recálculo de scouts)
+)
+
+// permissoes mapeia cada tipo de usuário às ações que ele pode executar.
+var permissoes = map[string][]Acao{
+	models.TipoUsuario:       {Consultar},
+	models.TipoJogador:       {Consultar, Inscrever},
+	models.TipoGestorClube:   {Consultar, Inscrever, GerenciarClubes},
+	models.TipoGestorTorneio: {Consultar, Inscrever, GerenciarTorneios, RegistrarResultados},
+	models.TipoAdmin: {Consultar, Inscrever, GerenciarTorneios, RegistrarResultados, GerenciarClubes,
+		GerenciarUsuarios, ConfigurarEsportes, Administrar},
+}
+
+// MensagemPermissaoNegada é o corpo das respostas 403 das rotas protegidas por permissão.
+const MensagemPermissaoNegada = "Você não tem permissão para executar esta ação."
+
+// Permite informa se o tipo de usuário pode executar a ação. Tipos desconhecidos não podem
+// executar nenhuma ação.
+func Permite(tipo string, acao Acao) bool {
+	for _, a := range permissoes[tipo] {
+		if a == acao {
+			return true
+		}
+	}
+	return false
+}
+
+// PodeExecutar informa se o usuário autenticado na requisição pode executar a ação. É
+// usado pelos handlers em verificações que dependem dos dados enviados.
+func PodeExecutar(c *gin.Context, acao Acao) bool {
+	return Permite(tipoUsuario(c), acao)
+}
+
+// UsuarioID retorna o ID do usuário autenticado, lido das claims do token, ou zero se ausente.
+func UsuarioID(c *gin.Context) int {
+	id, _ := jwt.ExtractClaims(c)[identityKey].(float64)
+	return int(id)
+}
+
+// tipoUsuario retorna o tipo do usuário autenticado, lido das claims do token.
+func tipoUsuario(c *gin.Context) string {
+	tipo, _ := jwt.ExtractClaims(c)["type"].(string)
+	return tipo
+}
+
+// negarPermissao interrompe a requisição com a resposta 403 padrão.
+func negarPermissao(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": MensagemPermissaoNegada})
+}
+
+// RequerPermissao restringe a rota aos tipos de usuário que podem executar a ação. Deve
+// ser usado depois do middleware de autenticação, que valida o token e disponibiliza as claims.
+func RequerPermissao(acao Acao) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !PodeExecutar(c, acao) {
+			negarPermissao(c)
+			return
+		}
+		c.Next()
+	}
+}
+
+// VerificadorOrganizador informa se o usuário organiza (criou ou é coorganizador) o torneio
+// ou clube do recurso com o ID informado. Deve retornar pgx.ErrNoRows se o recurso não existir.
+type VerificadorOrganizador func(ctx context.Context, id, usuarioID int) (bool, error)
+
+// AutorizarOrganizador verifica se o usuário autenticado pode executar a ação sobre o recurso:
+// o tipo do usuário precisa permitir a ação e, exceto para administradores, o usuário precisa
+// organizar o recurso. Em caso negativo, escreve a resposta de erro e retorna falso.
+func AutorizarOrganizador(c *gin.Context, acao Acao, verificar VerificadorOrganizador, id int) bool {
+	if !PodeExecutar(c, acao) {
+		negarPermissao(c)
+		return false
+	}
+	if PodeExecutar(c, Administrar) {
+		return true
+	}
+
+	organiza, err := verificar(c.Request.Context(), id, UsuarioID(c))
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Recurso não encontrado."})
+		return false
+	case err != nil:
+		log.Printf("Erro ao verificar organizador do recurso %d: %v", id, err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Ocorreu um erro ao verificar as permissões."})
+		return false
+	case !organiza:
+		negarPermissao(c)
+		return false
+	}
+	return true
+}
+
+// RequerOrganizador restringe a rota aos organizadores do recurso identificado pelo
+// parâmetro :id (e aos administradores), desde que o tipo do usuário permita a ação.
+func RequerOrganizador(acao Acao, verificar VerificadorOrganizador) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+			return
+		}
+		if !AutorizarOrganizador(c, acao, verificar, id) {
+			return
+		}
+		c.Next()
+	}
+}
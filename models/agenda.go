@@ -0,0 +1,181 @@
+package models
+
+import (
+	"competitions/validation"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrJanelaDiariaInvalida é retornado quando o fim da janela diária não é posterior ao início.
+var ErrJanelaDiariaInvalida = errors.New("o horário de fim do dia deve ser posterior ao horário de início")
+
+// AgendaInput define a janela diária e os intervalos usados no agendamento dos jogos de um torneio.
+// A quantidade de quadras e o período (início/fim) são lidos do próprio torneio.
+//
+//	@Description	AgendaInput é uma estrutura que contém os parâmetros para agendar os jogos pendentes de um torneio.
+type AgendaInput struct {
+	HoraInicioDia   string `json:"hora_inicio_dia" validate:"required,datetime=15:04"` // Ex.: "08:00"
+	HoraFimDia      string `json:"hora_fim_dia" validate:"required,datetime=15:04"`    // Ex.: "22:00"
+	DescansoMinutos int    `json:"descanso_minutos" validate:"gte=0"`                  // Descanso mínimo de um jogador entre dois jogos
+	DuracaoMinutos  int    `json:"duracao_minutos" validate:"omitempty,gt=0"`          // Substitui a duração estimada pelo formato das partidas
+}
+
+// Validate executa a validação na estrutura AgendaInput.
+func (a *AgendaInput) Validate() error {
+	return validation.ValidateStruct(a)
+}
+
+// Janela retorna os horários de início e fim da janela diária, a partir da meia-noite.
+func (a *AgendaInput) Janela() (inicio, fim time.Duration, err error) {
+	if inicio, err = HorarioDoDia(a.HoraInicioDia); err != nil {
+		return 0, 0, err
+	}
+	if fim, err = HorarioDoDia(a.HoraFimDia); err != nil {
+		return 0, 0, err
+	}
+	if fim <= inicio {
+		return 0, 0, ErrJanelaDiariaInvalida
+	}
+	return inicio, fim, nil
+}
+
+// JogoAgendavel é um jogo pendente a ser agendado. Jogadores contém os IDs de todos os
+// jogadores envolvidos (em duplas, os quatro), usados para evitar conflitos de horário.
+// Duracao, quando maior que zero, substitui a duração padrão da configuração.
+type JogoAgendavel struct {
+	JogoID    int
+	Rodada    int
+	Jogadores []int
+	Duracao   time.Duration
+}
+
+// ConfiguracaoAgenda reúne as restrições do agendamento.
+type ConfiguracaoAgenda struct {
+	Quadras   int
+	Inicio    time.Time     // Primeiro instante em que um jogo pode começar.
+	Fim       time.Time     // Nenhum jogo pode terminar depois deste instante.
+	InicioDia time.Duration // Horário de início da janela diária, a partir da meia-noite.
+	FimDia    time.Duration // Horário de fim da janela diária, a partir da meia-noite.
+	Duracao   time.Duration // Duração dos jogos que não definem a própria.
+	Descanso  time.Duration
+}
+
+// AlocacaoJogo é o horário e a quadra atribuídos a um jogo.
+type AlocacaoJogo struct {
+	JogoID   int       `json:"id_jogo"`
+	Quadra   int       `json:"quadra"`
+	DataHora time.Time `json:"data_hora"`
+}
+
+// ResultadoAgenda é a resposta do agendamento: os jogos alocados e os que não couberam no período do torneio.
+type ResultadoAgenda struct {
+	Alocacoes    []AlocacaoJogo `json:"alocacoes"`
+	NaoAgendados []int          `json:"nao_agendados"`
+}
+
+// HorarioDoDia converte um horário no formato "HH:MM" na duração a partir da meia-noite.
+func HorarioDoDia(horario string) (time.Duration, error) {
+	t, err := time.Parse("15:04", horario)
+	if err != nil {
+		return 0, fmt.Errorf("horário inválido '%s': %w", horario, err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// AgendarJogos distribui os jogos nas quadras de forma gulosa: a cada passo, escolhe o
+// jogo que pode começar mais cedo (em caso de empate, o de rodada menor) e o coloca na
+// quadra que o permite. Um jogo só começa quando a quadra está livre e todos os seus
+// jogadores cumpriram o descanso mínimo desde o último jogo, e precisa caber inteiro na
+// janela diária. Jogos que não cabem até o fim do torneio são devolvidos em NaoAgendados.
+func AgendarJogos(jogos []JogoAgendavel, cfg ConfiguracaoAgenda) ResultadoAgenda {
+	resultado := ResultadoAgenda{Alocacoes: []AlocacaoJogo{}, NaoAgendados: []int{}}
+	if cfg.Quadras < 1 {
+		for _, j := range jogos {
+			resultado.NaoAgendados = append(resultado.NaoAgendados, j.JogoID)
+		}
+		return resultado
+	}
+
+	quadraLivre := make([]time.Time, cfg.Quadras)
+	for i := range quadraLivre {
+		quadraLivre[i] = cfg.Inicio
+	}
+	jogadorLivre := map[int]time.Time{}
+
+	// Jogos sem duração válida ou mais longos que a janela diária nunca podem ser agendados.
+	pendentes := make([]JogoAgendavel, 0, len(jogos))
+	for _, j := range jogos {
+		if j.Duracao <= 0 {
+			j.Duracao = cfg.Duracao
+		}
+		if j.Duracao <= 0 || cfg.FimDia-cfg.InicioDia < j.Duracao {
+			resultado.NaoAgendados = append(resultado.NaoAgendados, j.JogoID)
+			continue
+		}
+		pendentes = append(pendentes, j)
+	}
+	sort.SliceStable(pendentes, func(a, b int) bool {
+		if pendentes[a].Rodada != pendentes[b].Rodada {
+			return pendentes[a].Rodada < pendentes[b].Rodada
+		}
+		return pendentes[a].JogoID < pendentes[b].JogoID
+	})
+
+	for len(pendentes) > 0 {
+		melhor, melhorQuadra := -1, -1
+		var melhorInicio time.Time
+		for i, j := range pendentes {
+			pronto := cfg.Inicio
+			for _, jogador := range j.Jogadores {
+				if livre, ok := jogadorLivre[jogador]; ok && livre.After(pronto) {
+					pronto = livre
+				}
+			}
+			for q, livre := range quadraLivre {
+				inicio := pronto
+				if livre.After(inicio) {
+					inicio = livre
+				}
+				inicio = cfg.ajustarJanela(inicio, j.Duracao)
+				if inicio.Add(j.Duracao).After(cfg.Fim) {
+					continue
+				}
+				if melhor == -1 || inicio.Before(melhorInicio) {
+					melhor, melhorQuadra, melhorInicio = i, q, inicio
+				}
+			}
+		}
+
+		if melhor == -1 {
+			for _, j := range pendentes {
+				resultado.NaoAgendados = append(resultado.NaoAgendados, j.JogoID)
+			}
+			break
+		}
+
+		j := pendentes[melhor]
+		fim := melhorInicio.Add(j.Duracao)
+		quadraLivre[melhorQuadra] = fim
+		for _, jogador := range j.Jogadores {
+			jogadorLivre[jogador] = fim.Add(cfg.Descanso)
+		}
+		resultado.Alocacoes = append(resultado.Alocacoes, AlocacaoJogo{JogoID: j.JogoID, Quadra: melhorQuadra + 1, DataHora: melhorInicio})
+		pendentes = append(pendentes[:melhor], pendentes[melhor+1:]...)
+	}
+	return resultado
+}
+
+// ajustarJanela retorna o primeiro instante, a partir de t, em que um jogo com a duração
+// informada cabe inteiro na janela diária.
+func (cfg ConfiguracaoAgenda) ajustarJanela(t time.Time, duracao time.Duration) time.Time {
+	dia := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if t.Before(dia.Add(cfg.InicioDia)) {
+		return dia.Add(cfg.InicioDia)
+	}
+	if t.Add(duracao).After(dia.Add(cfg.FimDia)) {
+		return dia.AddDate(0, 0, 1).Add(cfg.InicioDia)
+	}
+	return t
+}
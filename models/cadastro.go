@@ -0,0 +1,29 @@
+package models
+
+import (
+	"competitions/validation"
+	"time"
+)
+
+// SignupInput é usado no cadastro público de jogadores. A conta criada é sempre do tipo
+// 'jogador' e ativa; os tipos com privilégios só podem ser atribuídos por administradores.
+//
+//	@Description	SignupInput contém os dados para o cadastro público de um jogador.
+type SignupInput struct {
+	Tipo           string    `json:"tipo,omitempty" validate:"omitempty,eq=jogador"` // Opcional; qualquer valor diferente de 'jogador' é rejeitado
+	Nome           string    `json:"nome" validate:"required,max=100"`
+	Username       string    `json:"username" validate:"required,max=50"`
+	CPF            string    `json:"cpf" validate:"required,max=14"`
+	DataNascimento time.Time `json:"data_nascimento" validate:"required"` // Formato: "YYYY-MM-DD"
+	Email          string    `json:"email" validate:"required,email,max=100"`
+	Password       string    `json:"password" validate:"required,min=8,max=255"`
+	Telefone       string    `json:"telefone" validate:"required,min=9,max=20"`
+	Instagram      string    `json:"instagram" validate:"max=50"`
+	Sexo           string    `json:"sexo" validate:"required,oneof=M F"`
+	TipoMao        string    `json:"tipo_mao" validate:"required,oneof=destro canhoto"`
+}
+
+// Validate executa as regras de validação na estrutura SignupInput.
+func (si *SignupInput) Validate() error {
+	return validation.ValidateStruct(si)
+}
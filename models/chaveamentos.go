@@ -0,0 +1,210 @@
+package models
+
+import (
+	"competitions/validation"
+	"errors"
+	"fmt"
+)
+
+// ErrInscricoesInsuficientesChave é retornado quando não há inscrições suficientes para montar uma chave.
+var ErrInscricoesInsuficientesChave = errors.New("é necessário ter no mínimo 2 inscrições para gerar o chaveamento")
+
+// Chaveamento representa a chave de eliminatória simples de uma categoria de um torneio.
+type Chaveamento struct {
+	ID             int                  `json:"id"`
+	TorneioID      int                  `json:"id_torneio"`
+	CategoriaID    int                  `json:"id_categoria"`
+	TipoModalidade string               `json:"tipo_modalidade"`
+	Tamanho        int                  `json:"tamanho"`
+	Partidas       []PartidaChaveamento `json:"partidas"`
+}
+
+// PartidaChaveamento representa uma posição fixa da chave. Os lados referenciam inscrições
+// (jogadores_torneios) e ficam nulos enquanto o participante não for conhecido; na primeira
+// rodada, um lado nulo indica um "bye". O jogo só é criado quando os dois lados estão definidos.
+type PartidaChaveamento struct {
+	ID                  int    `json:"id"`
+	ChaveamentoID       int    `json:"id_chaveamento"`
+	Rodada              int    `json:"rodada"`
+	Posicao             int    `json:"posicao"`
+	RodadaID            int    `json:"id_rodada"`
+	NomeRodada          string `json:"nome_rodada"`
+	Inscricao1ID        *int   `json:"id_inscricao1,omitempty"`
+	Inscricao2ID        *int   `json:"id_inscricao2,omitempty"`
+	Seed1               *int   `json:"seed1,omitempty"`
+	Seed2               *int   `json:"seed2,omitempty"`
+	InscricaoVencedorID *int   `json:"id_inscricao_vencedora,omitempty"`
+	JogoID              *int   `json:"id_jogo,omitempty"`
+}
+
+// CriarChaveamentoInput define a categoria do torneio cuja chave será sorteada.
+//
+//	@Description	CriarChaveamentoInput é uma estrutura que contém a categoria para geração do chaveamento.
+type CriarChaveamentoInput struct {
+	CategoriaID int `json:"id_categoria" validate:"required,gt=0"`
+}
+
+// Validate executa a validação na estrutura CriarChaveamentoInput.
+func (c *CriarChaveamentoInput) Validate() error {
+	return validation.ValidateStruct(c)
+}
+
+// SlotChave é uma posição da primeira rodada da chave. InscricaoID igual a zero indica um "bye".
+type SlotChave struct {
+	InscricaoID int
+	Seed        int
+}
+
+// TamanhoChave retorna a menor potência de 2 capaz de acomodar a quantidade de participantes.
+func TamanhoChave(participantes int) int {
+	tamanho := 2
+	for tamanho < participantes {
+		tamanho *= 2
+	}
+	return tamanho
+}
+
+// PosicoesCabecasDeChave retorna, para cada posição da primeira rodada de uma chave
+// do tamanho informado, o número do cabeça de chave que a ocupa. A ordem segue o
+// padrão usado no tênis: o 1 e o 2 ficam em metades opostas, o 3 e o 4 em quartos
+// opostos, e assim por diante, de modo que em cada confronto a soma dos seeds é
+// tamanho+1 (1 x 16, 8 x 9, ...).
+func PosicoesCabecasDeChave(tamanho int) []int {
+	posicoes := []int{1}
+	for len(posicoes) < tamanho {
+		soma := 2*len(posicoes) + 1
+		proximas := make([]int, 0, 2*len(posicoes))
+		for _, seed := range posicoes {
+			proximas = append(proximas, seed, soma-seed)
+		}
+		posicoes = proximas
+	}
+	return posicoes
+}
+
+// DistribuirCabecasDeChave posiciona as inscrições, já ordenadas do melhor para o pior
+// rating, nas posições padrão da chave. Quando a quantidade de inscrições não é uma
+// potência de 2, as posições dos seeds inexistentes ficam vazias, o que concede "byes"
+// aos melhores colocados.
+func DistribuirCabecasDeChave(inscricoes []int) ([]SlotChave, error) {
+	if len(inscricoes) < 2 {
+		return nil, ErrInscricoesInsuficientesChave
+	}
+
+	posicoes := PosicoesCabecasDeChave(TamanhoChave(len(inscricoes)))
+	slots := make([]SlotChave, len(posicoes))
+	for i, seed := range posicoes {
+		if seed <= len(inscricoes) {
+			slots[i] = SlotChave{InscricaoID: inscricoes[seed-1], Seed: seed}
+		}
+	}
+	return slots, nil
+}
+
+// NomeRodadaChave retorna o nome de uma rodada da chave a partir da quantidade de
+// participantes que ainda disputam essa rodada.
+func NomeRodadaChave(participantes int) string {
+	switch participantes {
+	case 2:
+		return "Final"
+	case 4:
+		return "Semifinal"
+	case 8:
+		return "Quartas de final"
+	case 16:
+		return "Oitavas de final"
+	default:
+		return fmt.Sprintf("Rodada de %d", participantes)
+	}
+}
+
+// CriarPlayoffsInput define a categoria e quantos classificados de cada grupo avançam para a fase eliminatória.
+//
+//	@Description	CriarPlayoffsInput é uma estrutura que contém os parâmetros para gerar a fase eliminatória a partir dos grupos.
+type CriarPlayoffsInput struct {
+	CategoriaID           int `json:"id_categoria" validate:"required,gt=0"`
+	ClassificadosPorGrupo int `json:"classificados_por_grupo" validate:"omitempty,gte=1"` // Padrão: 2
+}
+
+// Validate executa a validação na estrutura CriarPlayoffsInput.
+func (c *CriarPlayoffsInput) Validate() error {
+	return validation.ValidateStruct(c)
+}
+
+// CruzarClassificadosGrupos monta a primeira rodada da fase eliminatória a partir dos
+// classificados de cada grupo, informados na ordem de classificação (classificados[g][0]
+// é o primeiro colocado do grupo g).
+//
+// Com dois classificados por grupo, número par de grupos e chave completa, é usado o
+// cruzamento clássico: os grupos são emparelhados (A/B, C/D, ...) e os confrontos
+// A1 x B2 e B1 x A2 ficam em metades opostas da chave, de modo que os vencedores de
+// grupos emparelhados só podem se reencontrar na final. Nos demais casos, os
+// classificados recebem seeds por colocação (todos os primeiros, depois os segundos,
+// ...) e são distribuídos como cabeças de chave, evitando sempre que possível
+// confrontos entre classificados do mesmo grupo na primeira rodada.
+func CruzarClassificadosGrupos(classificados [][]int) ([]SlotChave, error) {
+	grupos := len(classificados)
+	porGrupo := 0
+	if grupos > 0 {
+		porGrupo = len(classificados[0])
+	}
+	for _, c := range classificados {
+		if len(c) != porGrupo {
+			return nil, fmt.Errorf("todos os grupos devem ter a mesma quantidade de classificados")
+		}
+	}
+
+	// Seeds por colocação: primeiros colocados na ordem dos grupos, depois os segundos, etc.
+	var ordenados []int
+	grupoDe := map[int]int{}
+	seedDe := map[int]int{}
+	for pos := 0; pos < porGrupo; pos++ {
+		for g := 0; g < grupos; g++ {
+			ordenados = append(ordenados, classificados[g][pos])
+			grupoDe[classificados[g][pos]] = g
+			seedDe[classificados[g][pos]] = len(ordenados)
+		}
+	}
+	if len(ordenados) < 2 {
+		return nil, ErrInscricoesInsuficientesChave
+	}
+
+	slot := func(inscricao int) SlotChave {
+		return SlotChave{InscricaoID: inscricao, Seed: seedDe[inscricao]}
+	}
+
+	if porGrupo == 2 && grupos%2 == 0 && TamanhoChave(2*grupos) == 2*grupos {
+		metade := make([]SlotChave, 0, grupos)
+		outraMetade := make([]SlotChave, 0, grupos)
+		for g := 0; g < grupos; g += 2 {
+			x, y := classificados[g], classificados[g+1]
+			metade = append(metade, slot(x[0]), slot(y[1]))
+			outraMetade = append(outraMetade, slot(y[0]), slot(x[1]))
+		}
+		return append(metade, outraMetade...), nil
+	}
+
+	slots, err := DistribuirCabecasDeChave(ordenados)
+	if err != nil {
+		return nil, err
+	}
+	mesmoGrupo := func(a, b SlotChave) bool {
+		return a.InscricaoID != 0 && b.InscricaoID != 0 && grupoDe[a.InscricaoID] == grupoDe[b.InscricaoID]
+	}
+	for i := 0; i < len(slots); i += 2 {
+		if !mesmoGrupo(slots[i], slots[i+1]) {
+			continue
+		}
+		// Troca o lado de menor seed com o de outro confronto, se isso não gerar novo conflito.
+		for j := 0; j < len(slots); j += 2 {
+			if j == i || slots[j+1].InscricaoID == 0 {
+				continue
+			}
+			if !mesmoGrupo(slots[i], slots[j+1]) && !mesmoGrupo(slots[j], slots[i+1]) {
+				slots[i+1], slots[j+1] = slots[j+1], slots[i+1]
+				break
+			}
+		}
+	}
+	return slots, nil
+}
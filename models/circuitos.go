@@ -0,0 +1,199 @@
+package models
+
+import (
+	"competitions/validation"
+	"sort"
+	"time"
+)
+
+// ColocacaoParticipacao identifica, nos resultados de um circuito, quem disputou o torneio
+// mas não chegou à fase eliminatória (ex.: eliminados na fase de grupos).
+const ColocacaoParticipacao = 0
+
+// Circuito representa uma temporada de ranking que agrupa torneios de um mesmo esporte.
+//
+//	@Description	Circuito é uma estrutura que representa um circuito (temporada) de torneios.
+type Circuito struct {
+	ID                 int                 `json:"id"`
+	EsporteID          int                 `json:"id_esporte"`
+	Nome               string              `json:"nome"`
+	Temporada          int                 `json:"temporada"`
+	MelhoresResultados *int                `json:"melhores_resultados,omitempty"` // Nulo soma todos os resultados
+	PontosParticipacao int                 `json:"pontos_participacao"`
+	CriadorID          *int                `json:"id_usuario_criador,omitempty"`
+	CriadoEm           time.Time           `json:"criado_em"`
+	Pontuacao          []PontuacaoCircuito `json:"pontuacao"`
+	Torneios           []TorneioCircuito   `json:"torneios,omitempty"`
+}
+
+// PontuacaoCircuito define os pontos concedidos a quem é eliminado com a colocação informada.
+// A colocação é a quantidade de participantes da rodada em que o jogador parou:
+// 1 = campeão, 2 = finalista, 4 = semifinal, 8 = quartas de final, e assim por diante.
+type PontuacaoCircuito struct {
+	Colocacao int    `json:"colocacao" validate:"oneof=1 2 4 8 16 32 64 128"`
+	Fase      string `json:"fase,omitempty"`
+	Pontos    int    `json:"pontos" validate:"gte=0"`
+}
+
+// TorneioCircuito é um torneio que faz parte de um circuito.
+type TorneioCircuito struct {
+	ID         int       `json:"id"`
+	Nome       string    `json:"nome"`
+	DataInicio time.Time `json:"data_inicio"`
+	DataFim    time.Time `json:"data_fim"`
+}
+
+// PontuacaoCircuitoPadrao é a tabela de pontos usada quando o circuito é criado sem uma.
+var PontuacaoCircuitoPadrao = []PontuacaoCircuito{
+	{Colocacao: 1, Pontos: 100},
+	{Colocacao: 2, Pontos: 70},
+	{Colocacao: 4, Pontos: 45},
+	{Colocacao: 8, Pontos: 25},
+	{Colocacao: 16, Pontos: 15},
+}
+
+// CircuitoInput é usado para criar ou atualizar um circuito.
+//
+//	@Description	CircuitoInput é uma estrutura que contém os dados necessários para criar ou atualizar um circuito.
+type CircuitoInput struct {
+	EsporteID          int                 `json:"id_esporte" validate:"required,gt=0"`
+	Nome               string              `json:"nome" validate:"required,max=100"`
+	Temporada          int                 `json:"temporada" validate:"required,gte=2000,lte=2100"`
+	MelhoresResultados *int                `json:"melhores_resultados" validate:"omitempty,gte=1"`
+	PontosParticipacao int                 `json:"pontos_participacao" validate:"gte=0"`
+	Pontuacao          []PontuacaoCircuito `json:"pontuacao" validate:"omitempty,unique=Colocacao,dive"` // Se omitida, usa PontuacaoCircuitoPadrao
+}
+
+// Validate executa a validação na estrutura CircuitoInput.
+func (c *CircuitoInput) Validate() error {
+	return validation.ValidateStruct(c)
+}
+
+// TorneioCircuitoInput é usado para incluir um torneio em um circuito.
+//
+//	@Description	TorneioCircuitoInput contém o torneio a ser incluído no circuito.
+type TorneioCircuitoInput struct {
+	TorneioID int `json:"id_torneio" validate:"required,gt=0"`
+}
+
+// Validate executa a validação na estrutura TorneioCircuitoInput.
+func (t *TorneioCircuitoInput) Validate() error {
+	return validation.ValidateStruct(t)
+}
+
+// ResultadoCircuito é a colocação final de um jogador em um torneio do circuito.
+// Em duplas, cada jogador da dupla recebe o resultado da dupla.
+type ResultadoCircuito struct {
+	TorneioID   int
+	NomeTorneio string
+	JogadorID   int
+	NomeJogador string
+	Colocacao   int
+}
+
+// ResultadoJogadorCircuito é um resultado exibido na classificação do circuito.
+// Considerado indica se o resultado entra na soma dos N melhores.
+type ResultadoJogadorCircuito struct {
+	TorneioID   int    `json:"id_torneio"`
+	NomeTorneio string `json:"nome_torneio"`
+	Colocacao   int    `json:"colocacao"`
+	Fase        string `json:"fase"`
+	Pontos      int    `json:"pontos"`
+	Considerado bool   `json:"considerado"`
+}
+
+// LinhaClassificacaoCircuito é a posição de um jogador na classificação do circuito.
+type LinhaClassificacaoCircuito struct {
+	Posicao    int                        `json:"posicao"`
+	JogadorID  int                        `json:"id_jogador"`
+	Nome       string                     `json:"nome"`
+	Pontos     int                        `json:"pontos"`
+	Resultados []ResultadoJogadorCircuito `json:"resultados"`
+}
+
+// ClassificacaoCircuito é a classificação de uma categoria e modalidade de um circuito.
+type ClassificacaoCircuito struct {
+	CircuitoID     int                          `json:"id_circuito"`
+	CategoriaID    int                          `json:"id_categoria"`
+	TipoModalidade string                       `json:"tipo_modalidade"`
+	Classificacao  []LinhaClassificacaoCircuito `json:"classificacao"`
+}
+
+// NomeColocacao retorna o nome da fase correspondente a uma colocação do circuito.
+func NomeColocacao(colocacao int) string {
+	switch colocacao {
+	case ColocacaoParticipacao:
+		return "Participação"
+	case 1:
+		return "Campeão"
+	case 2:
+		return "Finalista"
+	default:
+		return NomeRodadaChave(colocacao)
+	}
+}
+
+// ColocacaoEliminado retorna a colocação de quem perdeu um jogo na rodada informada de uma
+// chave do tamanho informado: a quantidade de participantes daquela rodada.
+func ColocacaoEliminado(tamanhoChave, rodada int) int {
+	return tamanhoChave >> (rodada - 1)
+}
+
+// ClassificarCircuito soma os pontos de cada jogador a partir das colocações nos torneios do
+// circuito. Colocações sem pontuação definida na tabela recebem os pontos de participação.
+// Quando melhores é informado, apenas os N resultados de maior pontuação de cada jogador
+// entram na soma. Jogadores empatados em pontos dividem a mesma posição.
+func ClassificarCircuito(resultados []ResultadoCircuito, pontuacao []PontuacaoCircuito, pontosParticipacao int, melhores *int) []LinhaClassificacaoCircuito {
+	pontosPorColocacao := make(map[int]int, len(pontuacao))
+	for _, p := range pontuacao {
+		pontosPorColocacao[p.Colocacao] = p.Pontos
+	}
+
+	linhas := []LinhaClassificacaoCircuito{}
+	indice := map[int]int{}
+	for _, r := range resultados {
+		pontos, ok := pontosPorColocacao[r.Colocacao]
+		if !ok {
+			pontos = pontosParticipacao
+		}
+		i, ok := indice[r.JogadorID]
+		if !ok {
+			i = len(linhas)
+			indice[r.JogadorID] = i
+			linhas = append(linhas, LinhaClassificacaoCircuito{JogadorID: r.JogadorID, Nome: r.NomeJogador})
+		}
+		linhas[i].Resultados = append(linhas[i].Resultados, ResultadoJogadorCircuito{
+			TorneioID:   r.TorneioID,
+			NomeTorneio: r.NomeTorneio,
+			Colocacao:   r.Colocacao,
+			Fase:        NomeColocacao(r.Colocacao),
+			Pontos:      pontos,
+		})
+	}
+
+	for i := range linhas {
+		res := linhas[i].Resultados
+		sort.SliceStable(res, func(a, b int) bool { return res[a].Pontos > res[b].Pontos })
+		for j := range res {
+			if melhores != nil && j >= *melhores {
+				break
+			}
+			res[j].Considerado = true
+			linhas[i].Pontos += res[j].Pontos
+		}
+	}
+
+	sort.SliceStable(linhas, func(a, b int) bool {
+		if linhas[a].Pontos != linhas[b].Pontos {
+			return linhas[a].Pontos > linhas[b].Pontos
+		}
+		return linhas[a].Nome < linhas[b].Nome
+	})
+	for i := range linhas {
+		linhas[i].Posicao = i + 1
+		if i > 0 && linhas[i].Pontos == linhas[i-1].Pontos {
+			linhas[i].Posicao = linhas[i-1].Posicao
+		}
+	}
+	return linhas
+}
@@ -0,0 +1,218 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// Critérios de desempate aceitos na classificação dos grupos.
+const (
+	CriterioVitorias        = "vitorias"         // Jogos vencidos.
+	CriterioConfrontoDireto = "confronto_direto" // Vitórias nos jogos entre os empatados (minitorneio).
+	CriterioRazaoSets       = "razao_sets"       // Sets ganhos / sets perdidos.
+	CriterioRazaoPontos     = "razao_pontos"     // Games/pontos ganhos / games/pontos perdidos.
+	CriterioOrdemInscricao  = "ordem_inscricao"  // Último recurso quando todos os critérios empatam.
+)
+
+// CriteriosDesempatePadrao é a ordem de desempate usada quando o torneio não define outra.
+var CriteriosDesempatePadrao = []string{CriterioVitorias, CriterioConfrontoDireto, CriterioRazaoSets, CriterioRazaoPontos}
+
+// PartidaClassificacao é um jogo encerrado considerado no cálculo da classificação.
+// Os participantes são IDs de inscrições (jogadores_torneios) e os pontos de cada set
+// seguem a ordem dos lados (PontosJogador1 pertence ao Participante1).
+type PartidaClassificacao struct {
+	Participante1 int
+	Participante2 int
+	Vencedor      int
+	Sets          []SetInput
+}
+
+// LinhaClassificacao representa a campanha de um participante em um grupo e a sua posição.
+// CriterioDesempate indica o critério que definiu a posição em relação aos demais.
+type LinhaClassificacao struct {
+	Posicao           int    `json:"posicao"`
+	InscricaoID       int    `json:"id_inscricao"`
+	JogadorID         *int   `json:"id_jogador,omitempty"`
+	DuplaID           *int   `json:"id_dupla,omitempty"`
+	Nome              string `json:"nome"`
+	Jogos             int    `json:"jogos"`
+	Vitorias          int    `json:"vitorias"`
+	Derrotas          int    `json:"derrotas"`
+	SetsPro           int    `json:"sets_pro"`
+	SetsContra        int    `json:"sets_contra"`
+	PontosPro         int    `json:"pontos_pro"`
+	PontosContra      int    `json:"pontos_contra"`
+	CriterioDesempate string `json:"criterio_desempate,omitempty"`
+	Classificado      bool   `json:"classificado"`
+}
+
+// ClassificacaoGrupo é a tabela de classificação completa de um grupo.
+// Encerrado indica que todos os jogos do grupo já foram disputados; enquanto for falso,
+// a classificação e a indicação de classificados são parciais.
+type ClassificacaoGrupo struct {
+	Grupo     Grupo                `json:"grupo"`
+	Encerrado bool                 `json:"encerrado"`
+	Linhas    []LinhaClassificacao `json:"classificacao"`
+}
+
+// MarcarClassificados indica como classificados os primeiros colocados do grupo.
+func (c *ClassificacaoGrupo) MarcarClassificados(quantidade int) {
+	for i := range c.Linhas {
+		c.Linhas[i].Classificado = c.Linhas[i].Posicao <= quantidade
+	}
+}
+
+// CalcularClassificacao ordena os participantes de um grupo aplicando os critérios na
+// ordem informada. Quando um critério separa os empatados em subgrupos, cada subgrupo
+// com mais de um participante é desempatado novamente desde o primeiro critério,
+// considerando apenas os jogos entre eles no confronto direto; assim, um empate
+// triplo é resolvido por um minitorneio e, se ele eliminar apenas um participante,
+// os dois restantes são decididos pelo confronto direto entre si.
+func CalcularClassificacao(participantes []int, partidas []PartidaClassificacao, criterios []string) []LinhaClassificacao {
+	if len(criterios) == 0 {
+		criterios = CriteriosDesempatePadrao
+	}
+
+	linhas := make(map[int]*LinhaClassificacao, len(participantes))
+	for _, id := range participantes {
+		linhas[id] = &LinhaClassificacao{InscricaoID: id}
+	}
+	for _, p := range partidas {
+		l1, l2 := linhas[p.Participante1], linhas[p.Participante2]
+		if l1 == nil || l2 == nil {
+			continue
+		}
+		l1.Jogos++
+		l2.Jogos++
+		if p.Vencedor == p.Participante1 {
+			l1.Vitorias++
+			l2.Derrotas++
+		} else {
+			l2.Vitorias++
+			l1.Derrotas++
+		}
+		for _, s := range p.Sets {
+			l1.PontosPro += s.PontosJogador1
+			l1.PontosContra += s.PontosJogador2
+			l2.PontosPro += s.PontosJogador2
+			l2.PontosContra += s.PontosJogador1
+			switch lado, _ := s.LadoVencedorSet(); lado {
+			case 1:
+				l1.SetsPro++
+				l2.SetsContra++
+			case 2:
+				l2.SetsPro++
+				l1.SetsContra++
+			}
+		}
+	}
+
+	ordem := make([]int, len(participantes))
+	copy(ordem, participantes)
+	sort.Ints(ordem)
+
+	var resultado []LinhaClassificacao
+	for _, d := range desempatar(ordem, linhas, partidas, criterios, 0) {
+		l := *linhas[d.id]
+		l.Posicao = len(resultado) + 1
+		l.CriterioDesempate = d.criterio
+		resultado = append(resultado, l)
+	}
+	return resultado
+}
+
+// posicaoDesempatada associa um participante ao critério que o separou dos demais.
+type posicaoDesempatada struct {
+	id       int
+	criterio string
+}
+
+// desempatar ordena os participantes empatados a partir do critério de índice i.
+func desempatar(ids []int, linhas map[int]*LinhaClassificacao, partidas []PartidaClassificacao, criterios []string, i int) []posicaoDesempatada {
+	if len(ids) == 1 {
+		return []posicaoDesempatada{{id: ids[0]}}
+	}
+	if i == len(criterios) {
+		// ids já está em ordem crescente de inscrição.
+		resultado := make([]posicaoDesempatada, len(ids))
+		for k, id := range ids {
+			resultado[k] = posicaoDesempatada{id: id, criterio: CriterioOrdemInscricao}
+		}
+		return resultado
+	}
+
+	criterio := criterios[i]
+	valores := valoresCriterio(criterio, ids, linhas, partidas)
+	ordenados := make([]int, len(ids))
+	copy(ordenados, ids)
+	sort.SliceStable(ordenados, func(a, b int) bool { return valores[ordenados[a]] > valores[ordenados[b]] })
+
+	// Separa os participantes em subgrupos de mesmo valor.
+	var subgrupos [][]int
+	for k, id := range ordenados {
+		if k == 0 || valores[id] != valores[ordenados[k-1]] {
+			subgrupos = append(subgrupos, nil)
+		}
+		subgrupos[len(subgrupos)-1] = append(subgrupos[len(subgrupos)-1], id)
+	}
+	if len(subgrupos) == 1 {
+		return desempatar(ids, linhas, partidas, criterios, i+1)
+	}
+
+	var resultado []posicaoDesempatada
+	for _, sub := range subgrupos {
+		if len(sub) == 1 {
+			resultado = append(resultado, posicaoDesempatada{id: sub[0], criterio: criterio})
+			continue
+		}
+		sort.Ints(sub)
+		resultado = append(resultado, desempatar(sub, linhas, partidas, criterios, 0)...)
+	}
+	return resultado
+}
+
+// valoresCriterio calcula o valor de um critério para cada participante. Valores maiores são melhores.
+func valoresCriterio(criterio string, ids []int, linhas map[int]*LinhaClassificacao, partidas []PartidaClassificacao) map[int]float64 {
+	valores := make(map[int]float64, len(ids))
+	switch criterio {
+	case CriterioVitorias:
+		for _, id := range ids {
+			valores[id] = float64(linhas[id].Vitorias)
+		}
+	case CriterioConfrontoDireto:
+		empatados := make(map[int]bool, len(ids))
+		for _, id := range ids {
+			empatados[id] = true
+			valores[id] = 0
+		}
+		for _, p := range partidas {
+			if empatados[p.Participante1] && empatados[p.Participante2] {
+				valores[p.Vencedor]++
+			}
+		}
+	case CriterioRazaoSets:
+		for _, id := range ids {
+			valores[id] = razao(linhas[id].SetsPro, linhas[id].SetsContra)
+		}
+	case CriterioRazaoPontos:
+		for _, id := range ids {
+			valores[id] = razao(linhas[id].PontosPro, linhas[id].PontosContra)
+		}
+	default:
+		for _, id := range ids {
+			valores[id] = 0
+		}
+	}
+	return valores
+}
+
+// razao calcula pro/contra; sem nada contra, qualquer valor a favor vale mais que qualquer razão finita.
+func razao(pro, contra int) float64 {
+	if contra == 0 {
+		if pro == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return float64(pro) / float64(contra)
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+// partida monta um jogo encerrado; os sets seguem a ordem dos participantes.
+func partida(p1, p2, vencedor int, sets ...SetInput) PartidaClassificacao {
+	return PartidaClassificacao{Participante1: p1, Participante2: p2, Vencedor: vencedor, Sets: sets}
+}
+
+// conferirClassificacao compara a ordem dos participantes e o critério que definiu cada posição.
+func conferirClassificacao(t *testing.T, linhas []LinhaClassificacao, ordem []int, criterios []string) {
+	t.Helper()
+	var ids []int
+	var usados []string
+	for i, l := range linhas {
+		if l.Posicao != i+1 {
+			t.Errorf("linha %d com posição %d", i, l.Posicao)
+		}
+		ids = append(ids, l.InscricaoID)
+		usados = append(usados, l.CriterioDesempate)
+	}
+	if !reflect.DeepEqual(ids, ordem) {
+		t.Errorf("ordem = %v, esperado %v", ids, ordem)
+	}
+	if !reflect.DeepEqual(usados, criterios) {
+		t.Errorf("critérios = %v, esperado %v", usados, criterios)
+	}
+}
+
+func TestCalcularClassificacaoEmpateDuploDecididoPeloConfrontoDireto(t *testing.T) {
+	// 1 e 2 têm duas vitórias e 3 e 4 têm uma; em cada par, vence quem ganhou o jogo entre eles,
+	// mesmo quando o vencedor se inscreveu depois.
+	partidas := []PartidaClassificacao{
+		partida(2, 1, 2),
+		partida(1, 3, 1),
+		partida(1, 4, 1),
+		partida(2, 3, 2),
+		partida(4, 2, 4),
+		partida(3, 4, 3),
+	}
+
+	linhas := CalcularClassificacao([]int{1, 2, 3, 4}, partidas, nil)
+
+	conferirClassificacao(t, linhas, []int{2, 1, 3, 4}, []string{
+		CriterioConfrontoDireto, CriterioConfrontoDireto, CriterioConfrontoDireto, CriterioConfrontoDireto,
+	})
+	if linhas[0].Vitorias != 2 || linhas[0].Derrotas != 1 || linhas[0].Jogos != 3 {
+		t.Errorf("campanha do primeiro colocado = %d-%d em %d jogos, esperado 2-1 em 3",
+			linhas[0].Vitorias, linhas[0].Derrotas, linhas[0].Jogos)
+	}
+}
+
+func TestCalcularClassificacaoEmpateTriploCiclicoDecididoPelaRazaoDeSets(t *testing.T) {
+	// 1 vence 2, 2 vence 3 e 3 vence 1: o confronto direto não separa ninguém e a ordem sai da
+	// razão de sets (1: 3/2, 3: 3/3, 2: 2/3).
+	partidas := []PartidaClassificacao{
+		partida(1, 2, 1, SetInput{6, 3}, SetInput{6, 4}),
+		partida(2, 3, 2, SetInput{6, 4}, SetInput{3, 6}, SetInput{6, 2}),
+		partida(3, 1, 3, SetInput{6, 4}, SetInput{4, 6}, SetInput{6, 3}),
+	}
+
+	linhas := CalcularClassificacao([]int{1, 2, 3}, partidas, nil)
+
+	conferirClassificacao(t, linhas, []int{1, 3, 2}, []string{CriterioRazaoSets, CriterioRazaoSets, CriterioRazaoSets})
+	if l := linhas[0]; l.SetsPro != 3 || l.SetsContra != 2 {
+		t.Errorf("sets do primeiro colocado = %d/%d, esperado 3/2", l.SetsPro, l.SetsContra)
+	}
+}
+
+func TestCalcularClassificacaoEmpateNaoDesfeitoUsaOrdemDeInscricao(t *testing.T) {
+	// Cada um vence um jogo pelo mesmo placar: todos os critérios empatam.
+	partidas := []PartidaClassificacao{
+		partida(1, 2, 1, SetInput{6, 4}),
+		partida(2, 3, 2, SetInput{6, 4}),
+		partida(3, 1, 3, SetInput{6, 4}),
+	}
+
+	linhas := CalcularClassificacao([]int{3, 1, 2}, partidas, nil)
+
+	conferirClassificacao(t, linhas, []int{1, 2, 3}, []string{
+		CriterioOrdemInscricao, CriterioOrdemInscricao, CriterioOrdemInscricao,
+	})
+}
+
+func TestCalcularClassificacaoOrdemDeCriteriosPersonalizada(t *testing.T) {
+	// 1 vence o jogo, mas 2 ganha mais games: 1 fica à frente pelas vitórias e 2 pela razão de pontos.
+	partidas := []PartidaClassificacao{
+		partida(1, 2, 1, SetInput{0, 6}, SetInput{7, 5}, SetInput{7, 5}),
+	}
+
+	casos := []struct {
+		nome      string
+		criterios []string
+		ordem     []int
+		criterio  string
+	}{
+		{"padrão", nil, []int{1, 2}, CriterioVitorias},
+		{"pontos antes das vitórias", []string{CriterioRazaoPontos, CriterioVitorias}, []int{2, 1}, CriterioRazaoPontos},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			linhas := CalcularClassificacao([]int{1, 2}, partidas, c.criterios)
+			conferirClassificacao(t, linhas, c.ordem, []string{c.criterio, c.criterio})
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"competitions/validation"
+	"time"
+)
+
+// Clube representa um clube esportivo, com contato, localização e quantidade de membros.
+//
+//	@Description	Clube é uma estrutura que representa um clube esportivo.
+type Clube struct {
+	ID                   int       `json:"id"`
+	JogadorResponsavelID *int      `json:"id_jogador_responsavel,omitempty"`
+	Nome                 string    `json:"nome"`
+	Telefone             string    `json:"telefone"`
+	Whatsapp             *string   `json:"whatsapp,omitempty"`
+	Instagram            *string   `json:"instagram,omitempty"`
+	CidadeID             int       `json:"id_cidade"`
+	EstadoID             int       `json:"id_estado"`
+	PaisID               int       `json:"id_pais"`
+	Quantidade           int       `json:"quantidade"` // Quantidade de membros, mantida por trigger
+	CriadorID            *int      `json:"id_usuario_criador,omitempty"`
+	Ativo                bool      `json:"ativo"`
+	CriadoEm             time.Time `json:"criado_em"`
+}
+
+// ClubeInput é usado para criar ou atualizar um clube.
+//
+//	@Description	ClubeInput é uma estrutura que contém os dados necessários para criar ou atualizar um clube.
+type ClubeInput struct {
+	JogadorResponsavelID *int   `json:"id_jogador_responsavel" validate:"omitempty,gt=0"`
+	Nome                 string `json:"nome" validate:"required,max=100"`
+	Telefone             string `json:"telefone" validate:"required,min=9,max=20"`
+	Whatsapp             string `json:"whatsapp" validate:"omitempty,min=9,max=20"`
+	Instagram            string `json:"instagram" validate:"max=50"`
+	CidadeID             int    `json:"id_cidade" validate:"required,gt=0"`
+	EstadoID             int    `json:"id_estado" validate:"required,gt=0"`
+	PaisID               int    `json:"id_pais" validate:"required,gt=0"`
+	Ativo                *bool  `json:"ativo"` // Se omitido, o clube é criado ativo e a situação não muda na atualização
+}
+
+// Validate executa as regras de validação na estrutura ClubeInput.
+func (ci *ClubeInput) Validate() error {
+	return validation.ValidateStruct(ci)
+}
+
+// OrganizadorClube é um usuário que pode alterar um clube: o criador ou um coorganizador.
+//
+//	@Description	OrganizadorClube é uma estrutura que representa um organizador de um clube.
+type OrganizadorClube struct {
+	UsuarioID int    `json:"id_usuario"`
+	Nome      string `json:"nome"`
+	Username  string `json:"username"`
+	Criador   bool   `json:"criador"`
+}
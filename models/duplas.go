@@ -0,0 +1,39 @@
+package models
+
+import "competitions/validation"
+
+// DuplaInput é usado para receber os dados de criação de uma dupla.
+// A ordem dos jogadores é indiferente: o repositório grava sempre o menor ID como
+// jogador A, conforme a constraint chk_jogador_ordem.
+//
+//	@Description	DuplaInput é uma estrutura que contém os dados necessários para criar uma dupla.
+type DuplaInput struct {
+	JogadorAID int     `json:"id_jogador_a" validate:"required,gt=0"`
+	JogadorBID int     `json:"id_jogador_b" validate:"required,gt=0,nefield=JogadorAID"`
+	NomeDupla  *string `json:"nome_dupla" validate:"omitempty,max=100"`
+}
+
+// Validate executa as regras de validação na estrutura DuplaInput.
+func (d *DuplaInput) Validate() error {
+	return validation.ValidateStruct(d)
+}
+
+// JogadoresOrdenados retorna os IDs dos jogadores com o menor primeiro.
+func (d *DuplaInput) JogadoresOrdenados() (int, int) {
+	if d.JogadorAID > d.JogadorBID {
+		return d.JogadorBID, d.JogadorAID
+	}
+	return d.JogadorAID, d.JogadorBID
+}
+
+// RenomearDuplaInput é usado para alterar o nome de uma dupla.
+//
+//	@Description	RenomearDuplaInput é uma estrutura que contém o novo nome de uma dupla.
+type RenomearDuplaInput struct {
+	NomeDupla *string `json:"nome_dupla" validate:"omitempty,max=100"` // Nulo remove o nome
+}
+
+// Validate executa as regras de validação na estrutura RenomearDuplaInput.
+func (d *RenomearDuplaInput) Validate() error {
+	return validation.ValidateStruct(d)
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"competitions/regras"
+	"competitions/validation"
+)
+
+// FormatoPartida define como as partidas de um torneio são disputadas. Sem categoria, é o
+// formato geral do torneio; com categoria, sobrepõe o formato geral nas partidas daquela
+// categoria. Campos nulos mantêm a regra oficial do esporte.
+//
+//	@Description	FormatoPartida é uma estrutura que representa o formato das partidas de um torneio ou de uma categoria.
+type FormatoPartida struct {
+	ID          int  `json:"id"`
+	TorneioID   int  `json:"id_torneio"`
+	CategoriaID *int `json:"id_categoria,omitempty"`
+	FormatoPartidaCampos
+	// Duração estimada de uma partida neste formato, derivada da duração do esporte.
+	DuracaoEstimadaMinutos int `json:"duracao_estimada_minutos"`
+}
+
+// FormatoPartidaCampos são as opções configuráveis do formato das partidas.
+type FormatoPartidaCampos struct {
+	MelhorDe              *int  `json:"melhor_de" validate:"omitempty,oneof=1 3 5"`
+	GamesPorSet           *int  `json:"games_por_set" validate:"omitempty,gte=1,lte=9"`    // Esportes contados em games
+	PontosPorGame         *int  `json:"pontos_por_game" validate:"omitempty,gte=1,lte=30"` // Em pontos corridos, os pontos de cada set
+	SemVantagem           *bool `json:"sem_vantagem"`                                      // No-ad: ponto decisivo em 40 x 40
+	TiebreakEmpate        *bool `json:"tiebreak_empate"`                                   // Tiebreak no empate em games (ex.: 6 x 6)
+	SuperTiebreakDecisivo *bool `json:"super_tiebreak_decisivo"`                           // Super tiebreak até 10 no lugar do último set
+}
+
+// FormatoPartidaInput é usado para definir o formato das partidas de um torneio ou de uma
+// de suas categorias.
+//
+//	@Description	FormatoPartidaInput é uma estrutura que contém o formato das partidas de um torneio ou categoria.
+type FormatoPartidaInput struct {
+	CategoriaID *int `json:"id_categoria" validate:"omitempty,gt=0"` // Nulo define o formato geral do torneio
+	FormatoPartidaCampos
+}
+
+// Validate executa a validação na estrutura FormatoPartidaInput.
+func (f *FormatoPartidaInput) Validate() error {
+	return validation.ValidateStruct(f)
+}
+
+// Formato converte as opções do formato para a estrutura usada pelo pacote regras.
+func (f FormatoPartidaCampos) Formato() regras.Formato {
+	return regras.Formato{
+		MelhorDe:              f.MelhorDe,
+		GamesPorSet:           f.GamesPorSet,
+		PontosPorGame:         f.PontosPorGame,
+		SemVantagem:           f.SemVantagem,
+		TiebreakEmpate:        f.TiebreakEmpate,
+		SuperTiebreakDecisivo: f.SuperTiebreakDecisivo,
+	}
+}
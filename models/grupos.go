@@ -19,15 +19,33 @@ type GrupoJogador struct {
 	JogadorID int `json:"id_jogador" db:"id_jogador"`
 }
 
-// GrupoComJogadores é uma estrutura para retornar um grupo com a lista de seus jogadores.
+// GrupoComJogadores é uma estrutura para retornar um grupo com a lista de seus participantes.
+// Em grupos de simples é preenchido Jogadores; em grupos de duplas, Duplas.
 type GrupoComJogadores struct {
 	Grupo
-	Jogadores []Usuario `json:"jogadores"`
+	Jogadores []Usuario       `json:"jogadores,omitempty"`
+	Duplas    []DuplaDetalhes `json:"duplas,omitempty"`
 }
 
+// Formas de calcular o rating de uma dupla na distribuição dos grupos.
+const (
+	RatingDuplaMedia = "media" // Média do rating dos dois jogadores.
+	RatingDuplaSoma  = "soma"  // Soma do rating dos dois jogadores.
+)
+
 // CriarGruposInput define os parâmetros para a criação de grupos.
 type CriarGruposInput struct {
-	CategoriaID int `json:"id_categoria" validate:"required,gt=0"`
+	CategoriaID    int    `json:"id_categoria" validate:"required,gt=0"`
+	TipoModalidade string `json:"tipo_modalidade" validate:"omitempty,oneof=simples duplas"` // Padrão: simples
+	RatingDupla    string `json:"rating_dupla" validate:"omitempty,oneof=media soma"`        // Padrão: media
+}
+
+// Modalidade retorna a modalidade informada ou "simples", quando omitida.
+func (c *CriarGruposInput) Modalidade() string {
+	if c.TipoModalidade == "" {
+		return "simples"
+	}
+	return c.TipoModalidade
 }
 
 // RankedPlayer armazena o ID e o rating de um jogador.
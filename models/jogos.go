@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"competitions/validation"
+	"time"
+)
 
 // Jogo representa uma partida dentro de um torneio, correspondendo à tabela 'jogos'.
 // Os campos foram atualizados para refletir a mudança de 'participantes' para 'jogadores_torneios'.
@@ -52,7 +55,7 @@ import "time"
 type Jogo struct {
 	ID                int       `json:"id"`
 	TorneioID         int       `json:"id_torneio"`
-	GrupoID           int       `json:"id_grupo"`
+	GrupoID           *int      `json:"id_grupo,omitempty"` // Nulo em jogos de chaveamento
 	RodadaID          int       `json:"id_rodada"`
 	JogadorTorneio1ID *int      `json:"id_jogador_torneio1,omitempty"` // ANTES: id_participante1
 	JogadorTorneio2ID *int      `json:"id_jogador_torneio2,omitempty"` // ANTES: id_participante2
@@ -68,3 +71,53 @@ type Jogo struct {
 	Situacao          string    `json:"situacao"`
 	EhFinalCampeonato bool      `json:"eh_final_campeonato"`
 }
+
+// JogoInput é usado para receber dados de entrada ao criar ou atualizar um jogo.
+// O grupo é opcional, pois jogos de fases eliminatórias não pertencem a um grupo.
+// Para jogos 'simples' devem ser informados os IDs das inscrições (jogadores_torneios);
+// para jogos de 'duplas', os IDs das duplas. A consistência entre os campos e a
+// modalidade segue a constraint chk_jogo_definicao_jogadores_torneios do banco.
+//
+//	@Description	JogoInput é uma estrutura que contém os dados necessários para criar ou atualizar um jogo.
+type JogoInput struct {
+	TorneioID         int        `json:"id_torneio" validate:"required,gt=0"`
+	GrupoID           *int       `json:"id_grupo" validate:"omitempty,gt=0"`
+	RodadaID          int        `json:"id_rodada" validate:"required,gt=0"`
+	JogadorTorneio1ID *int       `json:"id_jogador_torneio1" validate:"required_if=TipoModalidade simples,excluded_if=TipoModalidade duplas"`
+	JogadorTorneio2ID *int       `json:"id_jogador_torneio2" validate:"required_if=TipoModalidade simples,excluded_if=TipoModalidade duplas"`
+	Dupla1ID          *int       `json:"id_dupla1" validate:"required_if=TipoModalidade duplas,excluded_if=TipoModalidade simples"`
+	Dupla2ID          *int       `json:"id_dupla2" validate:"required_if=TipoModalidade duplas,excluded_if=TipoModalidade simples"`
+	TipoModalidade    string     `json:"tipo_modalidade" validate:"required,oneof=simples duplas"`
+	DataHora          *time.Time `json:"data_hora"`
+	Localizacao       *string    `json:"localizacao" validate:"omitempty,max=100"`
+	Situacao          string     `json:"situacao" validate:"omitempty,oneof=aguardando 'em andamento'"`
+	EhFinalCampeonato bool       `json:"eh_final_campeonato"`
+}
+
+// Validate executa as regras de validação na estrutura JogoInput.
+func (ji *JogoInput) Validate() error {
+	return validation.ValidateStruct(ji)
+}
+
+// ResultadoJogoInput informa qual lado venceu a partida.
+// O lado 1 corresponde a id_jogador_torneio1/id_dupla1 e o lado 2 a id_jogador_torneio2/id_dupla2.
+//
+//	@Description	ResultadoJogoInput é uma estrutura que contém o lado vencedor de um jogo.
+type ResultadoJogoInput struct {
+	LadoVencedor int `json:"lado_vencedor" validate:"required,oneof=1 2"`
+}
+
+// Validate executa as regras de validação na estrutura ResultadoJogoInput.
+func (ri *ResultadoJogoInput) Validate() error {
+	return validation.ValidateStruct(ri)
+}
+
+// FiltroJogos agrupa os filtros opcionais aceitos na listagem de jogos.
+// Campos com valor zero são ignorados.
+type FiltroJogos struct {
+	TorneioID int
+	GrupoID   int
+	RodadaID  int
+	Situacao  string
+	UsuarioID int // Jogos disputados pelo jogador do usuário, individualmente ou em dupla
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"competitions/validation"
+	"time"
+)
+
+// PerfilInput é usado pelo próprio usuário para atualizar seus dados cadastrais. O tipo e a
+// situação da conta não fazem parte do perfil e só podem ser alterados por administradores.
+//
+//	@Description	PerfilInput contém os dados cadastrais que o usuário autenticado pode alterar.
+type PerfilInput struct {
+	Nome           string    `json:"nome" validate:"required,max=100"`
+	Username       string    `json:"username" validate:"required,max=50"`
+	CPF            string    `json:"cpf" validate:"required,max=14"`
+	DataNascimento time.Time `json:"data_nascimento" validate:"required"` // Formato: "YYYY-MM-DD"
+	Email          string    `json:"email" validate:"required,email,max=100"`
+	Telefone       string    `json:"telefone" validate:"required,min=9,max=20"`
+	Instagram      string    `json:"instagram" validate:"max=50"`
+}
+
+// Validate executa as regras de validação na estrutura PerfilInput.
+func (pi *PerfilInput) Validate() error {
+	return validation.ValidateStruct(pi)
+}
+
+// RedefinirSenhaInput é usado por administradores para definir uma nova senha para um
+// usuário, sem a senha atual.
+//
+//	@Description	RedefinirSenhaInput contém a nova senha de um usuário.
+type RedefinirSenhaInput struct {
+	NewPassword string `json:"new_password" validate:"required,min=8,max=255"`
+}
+
+// Validate executa as regras de validação na estrutura RedefinirSenhaInput.
+func (ri *RedefinirSenhaInput) Validate() error {
+	return validation.ValidateStruct(ri)
+}
+
+// InscricaoUsuario é uma inscrição do jogador de um usuário em um torneio, individual ou
+// com uma dupla da qual ele faz parte.
+//
+//	@Description	InscricaoUsuario representa uma inscrição do usuário autenticado em um torneio.
+type InscricaoUsuario struct {
+	InscricaoID    int            `json:"inscricao_id"`
+	TorneioID      int            `json:"id_torneio"`
+	TorneioNome    string         `json:"nome_torneio"`
+	DataInicio     time.Time      `json:"data_inicio"`
+	DataFim        time.Time      `json:"data_fim"`
+	CategoriaID    int            `json:"id_categoria"`
+	TipoModalidade string         `json:"tipo_modalidade"`
+	Dupla          *DuplaDetalhes `json:"dupla,omitempty"` // Preenchido se for 'duplas'
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"competitions/regras"
+	"competitions/validation"
+	"time"
+)
+
+// EventoPontuacao é um evento da pontuação ao vivo de um jogo, correspondendo à tabela
+// 'eventos_pontuacao'. Lado é nulo em eventos do tipo 'let'.
+//
+//	@Description	EventoPontuacao é uma estrutura que representa um evento da pontuação ao vivo de um jogo.
+type EventoPontuacao struct {
+	ID       int       `json:"id"`
+	JogoID   int       `json:"id_jogo"`
+	Tipo     string    `json:"tipo"`
+	Lado     *int      `json:"lado,omitempty"`
+	CriadoEm time.Time `json:"criado_em"`
+}
+
+// EventoPontuacaoInput é um evento enviado pelo árbitro: ponto vencido pelo lado, falta
+// cometida pelo lado ou let (ponto repetido; o lado, se informado, é ignorado).
+//
+//	@Description	EventoPontuacaoInput é uma estrutura que contém um evento da pontuação ao vivo.
+type EventoPontuacaoInput struct {
+	Tipo string `json:"tipo" validate:"required,oneof=ponto falta let"`
+	Lado *int   `json:"lado" validate:"omitempty,oneof=1 2"` // Obrigatório em ponto e falta
+}
+
+// Validate executa as regras de validação na estrutura EventoPontuacaoInput.
+func (e *EventoPontuacaoInput) Validate() error {
+	return validation.ValidateStruct(e)
+}
+
+// Evento converte a entrada para o evento usado pelo pacote regras.
+func (e *EventoPontuacaoInput) Evento() regras.Evento {
+	ev := regras.Evento{Tipo: e.Tipo}
+	if e.Lado != nil && e.Tipo != regras.EventoLet {
+		ev.Lado = *e.Lado
+	}
+	return ev
+}
+
+// PlacarAoVivo é o placar de um jogo calculado a partir dos seus eventos de pontuação.
+// Sets contém os sets encerrados; Games é o placar de games do set em andamento (apenas
+// nos esportes contados em games) e Pontos o do game, tiebreak ou set em andamento.
+//
+//	@Description	PlacarAoVivo é uma estrutura que representa o placar ao vivo de um jogo.
+type PlacarAoVivo struct {
+	JogoID       int              `json:"id_jogo"`
+	Situacao     string           `json:"situacao"`
+	Sets         []SetInput       `json:"sets"`
+	Games        *SetInput        `json:"games,omitempty"`
+	Pontos       SetInput         `json:"pontos"`
+	Tiebreak     bool             `json:"tiebreak"`
+	LadoVencedor *int             `json:"lado_vencedor,omitempty"` // Preenchido quando a partida está decidida
+	Eventos      int              `json:"eventos"`
+	UltimoEvento *EventoPontuacao `json:"ultimo_evento,omitempty"`
+}
+
+// NovoPlacarAoVivo monta o placar ao vivo de um jogo a partir do estado calculado pelas regras do esporte.
+func NovoPlacarAoVivo(jogoID int, situacao string, regra regras.Regra, e regras.Estado, eventos []EventoPontuacao) PlacarAoVivo {
+	placar := PlacarAoVivo{
+		JogoID:   jogoID,
+		Situacao: situacao,
+		Sets:     SetsDoPlacar(e.Sets),
+		Pontos:   SetInput{PontosJogador1: e.Pontos.Lado1, PontosJogador2: e.Pontos.Lado2},
+		Tiebreak: e.Tiebreak,
+		Eventos:  len(eventos),
+	}
+	if regra.ContaGames() && !e.Tiebreak {
+		placar.Games = &SetInput{PontosJogador1: e.Games.Lado1, PontosJogador2: e.Games.Lado2}
+	}
+	if e.Vencedor != 0 {
+		placar.LadoVencedor = &e.Vencedor
+	}
+	if len(eventos) > 0 {
+		placar.UltimoEvento = &eventos[len(eventos)-1]
+	}
+	return placar
+}
+
+// SetsDoPlacar converte os sets calculados pelas regras no formato de entrada de sets de um jogo.
+func SetsDoPlacar(sets []regras.Set) []SetInput {
+	convertidos := make([]SetInput, len(sets))
+	for i, s := range sets {
+		convertidos[i] = SetInput{PontosJogador1: s.Lado1, PontosJogador2: s.Lado2}
+	}
+	return convertidos
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"competitions/validation"
+	"time"
+)
+
+// FiltroRanking define o esporte e os filtros opcionais do ranking de jogadores.
+// Estado e cidade são os dos clubes dos quais o jogador faz parte.
+//
+//	@Description	FiltroRanking contém os filtros aceitos na consulta do ranking.
+type FiltroRanking struct {
+	EsporteID      int    `json:"id_esporte" form:"id_esporte" validate:"required,gt=0"`
+	TipoModalidade string `json:"tipo_modalidade" form:"tipo_modalidade" validate:"omitempty,oneof=simples duplas"` // Padrão: simples
+	Sexo           string `json:"sexo" form:"sexo" validate:"omitempty,oneof=M F"`
+	IdadeMinima    int    `json:"idade_min" form:"idade_min" validate:"omitempty,gte=0"`
+	IdadeMaxima    int    `json:"idade_max" form:"idade_max" validate:"omitempty,gtefield=IdadeMinima"`
+	EstadoID       int    `json:"id_estado" form:"id_estado" validate:"omitempty,gt=0"`
+	CidadeID       int    `json:"id_cidade" form:"id_cidade" validate:"omitempty,gt=0"`
+	ClubeID        int    `json:"id_clube" form:"id_clube" validate:"omitempty,gt=0"`
+	PeriodoDias    int    `json:"periodo_dias" form:"periodo_dias" validate:"omitempty,gt=0"` // Padrão: 30
+	Page           int    `json:"page" form:"page" validate:"omitempty,gte=1"`                // Padrão: 1
+	Limit          int    `json:"limit" form:"limit" validate:"omitempty,gte=1,lte=100"`      // Padrão: 20
+}
+
+// Validate executa a validação na estrutura FiltroRanking.
+func (f *FiltroRanking) Validate() error {
+	return validation.ValidateStruct(f)
+}
+
+// AplicarPadroes preenche os campos opcionais omitidos com os valores padrão.
+func (f *FiltroRanking) AplicarPadroes() {
+	if f.TipoModalidade == "" {
+		f.TipoModalidade = "simples"
+	}
+	if f.PeriodoDias == 0 {
+		f.PeriodoDias = 30
+	}
+	if f.Page == 0 {
+		f.Page = 1
+	}
+	if f.Limit == 0 {
+		f.Limit = 20
+	}
+}
+
+// LinhaRanking é a posição de um jogador no ranking. PosicaoAnterior é a posição no início
+// do período, considerando o rating de cada jogador naquela data; fica nula para quem ainda
+// não tinha jogos. Movimento é positivo quando o jogador subiu.
+type LinhaRanking struct {
+	Posicao         int    `json:"posicao"`
+	JogadorID       int    `json:"id_jogador"`
+	Nome            string `json:"nome"`
+	Sexo            string `json:"sexo"`
+	Idade           int    `json:"idade"`
+	Rating          int    `json:"rating"`
+	Jogos           int    `json:"jogos"`
+	PosicaoAnterior *int   `json:"posicao_anterior,omitempty"`
+	Movimento       *int   `json:"movimento,omitempty"`
+}
+
+// Ranking é uma página do ranking de um esporte e modalidade.
+type Ranking struct {
+	EsporteID      int            `json:"id_esporte"`
+	TipoModalidade string         `json:"tipo_modalidade"`
+	Referencia     time.Time      `json:"referencia"` // Início do período usado no cálculo do movimento.
+	Page           int            `json:"page"`
+	Limit          int            `json:"limit"`
+	Total          int            `json:"total"`
+	Linhas         []LinhaRanking `json:"ranking"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"competitions/validation"
+	"time"
+)
+
+// ConfiguracaoRating define o algoritmo e os parâmetros usados para atualizar o rating
+// dos jogadores nos jogos de um esporte.
+type ConfiguracaoRating struct {
+	EsporteID int     `json:"id_esporte"`
+	Algoritmo string  `json:"algoritmo"` // "elo" ou "glicko2"
+	FatorK    int     `json:"fator_k"`   // Elo
+	Tau       float64 `json:"tau"`       // Glicko-2
+}
+
+// ConfiguracaoRatingInput é usado para alterar a configuração de rating de um esporte.
+//
+//	@Description	ConfiguracaoRatingInput é uma estrutura que contém o algoritmo e os parâmetros de rating de um esporte.
+type ConfiguracaoRatingInput struct {
+	Algoritmo string  `json:"algoritmo" validate:"required,oneof=elo glicko2"`
+	FatorK    int     `json:"fator_k" validate:"omitempty,gt=0"` // Padrão: 32
+	Tau       float64 `json:"tau" validate:"omitempty,gt=0"`     // Padrão: 0.5
+}
+
+// Validate executa a validação na estrutura ConfiguracaoRatingInput.
+func (c *ConfiguracaoRatingInput) Validate() error {
+	return validation.ValidateStruct(c)
+}
+
+// RatingJogador é o rating de um jogador em um esporte e modalidade.
+type RatingJogador struct {
+	JogadorID      int       `json:"id_jogador"`
+	EsporteID      int       `json:"id_esporte"`
+	Esporte        string    `json:"esporte"`
+	TipoModalidade string    `json:"tipo_modalidade"`
+	Rating         int       `json:"rating"`
+	Desvio         float64   `json:"desvio_rating"`
+	Jogos          int       `json:"jogos"`
+	AtualizadoEm   time.Time `json:"atualizado_em"`
+}
+
+// HistoricoRating é a variação de rating de um jogador em um jogo. Em simples o adversário é
+// identificado por JogadorAdversarioID; em duplas, por DuplaAdversariaID. RatingAdversario é o
+// rating médio do lado adversário antes do jogo.
+type HistoricoRating struct {
+	ID                  int       `json:"id"`
+	JogadorID           int       `json:"id_jogador"`
+	EsporteID           int       `json:"id_esporte"`
+	TipoModalidade      string    `json:"tipo_modalidade"`
+	JogoID              *int      `json:"id_jogo,omitempty"`
+	DataJogo            time.Time `json:"data_jogo"`
+	RatingAntes         int       `json:"rating_antes"`
+	RatingDepois        int       `json:"rating_depois"`
+	Delta               int       `json:"delta"`
+	JogadorAdversarioID *int      `json:"id_jogador_adversario,omitempty"`
+	DuplaAdversariaID   *int      `json:"id_dupla_adversaria,omitempty"`
+	RatingAdversario    int       `json:"rating_adversario"`
+}
+
+// FiltroHistoricoRating agrupa os filtros opcionais aceitos na linha do tempo de ratings.
+// Campos com valor zero são ignorados.
+type FiltroHistoricoRating struct {
+	EsporteID      int
+	TipoModalidade string
+	De             *time.Time // Inclusivo
+	Ate            *time.Time // Exclusivo
+}
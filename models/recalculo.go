@@ -0,0 +1,229 @@
+package models
+
+import (
+	"competitions/rating"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// JogoRecalculo é um jogo encerrado reprocessado no recálculo das estatísticas. Vencedores e
+// Perdedores contêm os IDs dos jogadores de cada lado (em duplas, os dois de cada dupla).
+type JogoRecalculo struct {
+	JogoID          int
+	EsporteID       int
+	TipoModalidade  string
+	DataHora        time.Time
+	Final           bool // eh_final_campeonato: a vitória vale um título.
+	Vencedores      []int
+	Perdedores      []int
+	JogadorVencedor *int
+	JogadorPerdedor *int
+	DuplaVencedora  *int
+	DuplaPerdedora  *int
+}
+
+// EstatisticasScout são as estatísticas agregadas de um jogador (tabela scouts).
+type EstatisticasScout struct {
+	Vitorias int `json:"vitorias"`
+	Derrotas int `json:"derrotas"`
+	Titulos  int `json:"titulos"`
+}
+
+// ChaveRating identifica o rating de um jogador em um esporte e modalidade.
+type ChaveRating struct {
+	JogadorID      int
+	EsporteID      int
+	TipoModalidade string
+}
+
+// EstadoRating é o rating recalculado de um jogador em um esporte e modalidade.
+type EstadoRating struct {
+	rating.Jogador
+	Jogos        int
+	AtualizadoEm time.Time
+}
+
+// ResultadoRecalculo reúne as estatísticas obtidas ao reprocessar todos os jogos encerrados.
+type ResultadoRecalculo struct {
+	Scouts    map[int]EstatisticasScout
+	Ratings   map[ChaveRating]EstadoRating
+	Historico []HistoricoRating
+}
+
+// RecalcularEstatisticas reprocessa os jogos, que devem estar em ordem cronológica, do zero:
+// conta vitórias, derrotas e títulos e aplica o algoritmo de rating de cada esporte, partindo
+// do rating inicial. Como na atualização feita a cada resultado, o rating é arredondado para
+// inteiro após cada jogo, de modo que o recálculo reproduz exatamente os valores incrementais.
+func RecalcularEstatisticas(jogos []JogoRecalculo, configuracoes map[int]rating.Configuracao) (ResultadoRecalculo, error) {
+	res := ResultadoRecalculo{
+		Scouts:    map[int]EstatisticasScout{},
+		Ratings:   map[ChaveRating]EstadoRating{},
+		Historico: []HistoricoRating{},
+	}
+
+	for _, jg := range jogos {
+		for _, id := range jg.Vencedores {
+			s := res.Scouts[id]
+			s.Vitorias++
+			if jg.Final {
+				s.Titulos++
+			}
+			res.Scouts[id] = s
+		}
+		for _, id := range jg.Perdedores {
+			s := res.Scouts[id]
+			s.Derrotas++
+			res.Scouts[id] = s
+		}
+
+		cfg, ok := configuracoes[jg.EsporteID]
+		if !ok {
+			cfg = rating.ConfiguracaoPadrao()
+		}
+		antesVencedores := estadosRecalculo(res.Ratings, jg, jg.Vencedores)
+		antesPerdedores := estadosRecalculo(res.Ratings, jg, jg.Perdedores)
+		depoisVencedores, depoisPerdedores, err := cfg.AtualizarPartida(antesVencedores, antesPerdedores)
+		if err != nil {
+			return res, fmt.Errorf("jogo %d: %w", jg.JogoID, err)
+		}
+
+		jogoID := jg.JogoID
+		registrar := func(jogadores []int, antes, depois []rating.Jogador, adversarios []rating.Jogador, jogadorAdv, duplaAdv *int) {
+			ratingAdv := ratingMedio(adversarios)
+			for i, id := range jogadores {
+				depois[i].Rating = math.Round(depois[i].Rating)
+				chave := ChaveRating{JogadorID: id, EsporteID: jg.EsporteID, TipoModalidade: jg.TipoModalidade}
+				estado := res.Ratings[chave]
+				res.Ratings[chave] = EstadoRating{Jogador: depois[i], Jogos: estado.Jogos + 1, AtualizadoEm: jg.DataHora}
+				res.Historico = append(res.Historico, HistoricoRating{
+					JogadorID:           id,
+					EsporteID:           jg.EsporteID,
+					TipoModalidade:      jg.TipoModalidade,
+					JogoID:              &jogoID,
+					DataJogo:            jg.DataHora,
+					RatingAntes:         int(antes[i].Rating),
+					RatingDepois:        int(depois[i].Rating),
+					Delta:               int(depois[i].Rating - antes[i].Rating),
+					JogadorAdversarioID: jogadorAdv,
+					DuplaAdversariaID:   duplaAdv,
+					RatingAdversario:    ratingAdv,
+				})
+			}
+		}
+		registrar(jg.Vencedores, antesVencedores, depoisVencedores, antesPerdedores, jg.JogadorPerdedor, jg.DuplaPerdedora)
+		registrar(jg.Perdedores, antesPerdedores, depoisPerdedores, antesVencedores, jg.JogadorVencedor, jg.DuplaVencedora)
+	}
+	return res, nil
+}
+
+// estadosRecalculo retorna o rating atual dos jogadores no esporte e na modalidade do jogo.
+func estadosRecalculo(ratings map[ChaveRating]EstadoRating, jg JogoRecalculo, jogadores []int) []rating.Jogador {
+	estados := make([]rating.Jogador, len(jogadores))
+	for i, id := range jogadores {
+		estado, ok := ratings[ChaveRating{JogadorID: id, EsporteID: jg.EsporteID, TipoModalidade: jg.TipoModalidade}]
+		if !ok {
+			estados[i] = rating.NovoJogador()
+			continue
+		}
+		estados[i] = estado.Jogador
+	}
+	return estados
+}
+
+// ratingMedio retorna o rating médio dos jogadores, arredondado.
+func ratingMedio(jogadores []rating.Jogador) int {
+	var soma float64
+	for _, j := range jogadores {
+		soma += j.Rating
+	}
+	return int(math.Round(soma / float64(len(jogadores))))
+}
+
+// DiferencaScout compara as estatísticas gravadas de um jogador com as recalculadas.
+type DiferencaScout struct {
+	JogadorID   int               `json:"id_jogador"`
+	Atual       EstatisticasScout `json:"atual"`
+	Recalculado EstatisticasScout `json:"recalculado"`
+}
+
+// DiferencaRating compara o rating gravado de um jogador em um esporte e modalidade com o recalculado.
+type DiferencaRating struct {
+	JogadorID         int    `json:"id_jogador"`
+	EsporteID         int    `json:"id_esporte"`
+	TipoModalidade    string `json:"tipo_modalidade"`
+	RatingAtual       int    `json:"rating_atual"`
+	RatingRecalculado int    `json:"rating_recalculado"`
+	JogosAtual        int    `json:"jogos_atual"`
+	JogosRecalculado  int    `json:"jogos_recalculado"`
+}
+
+// RelatorioRecalculo descreve as diferenças encontradas no recálculo. Em uma simulação
+// nada é gravado; caso contrário, as diferenças listadas já foram corrigidas.
+type RelatorioRecalculo struct {
+	Simulacao        bool              `json:"simulacao"`
+	JogosProcessados int               `json:"jogos_processados"`
+	Scouts           []DiferencaScout  `json:"scouts"`
+	Ratings          []DiferencaRating `json:"ratings"`
+}
+
+// CompararRecalculo monta o relatório de diferenças entre as estatísticas gravadas e as recalculadas.
+// Jogadores ausentes em um dos lados são tratados como sem jogos (rating inicial).
+func CompararRecalculo(scoutsAtuais map[int]EstatisticasScout, ratingsAtuais map[ChaveRating]EstadoRating, res ResultadoRecalculo) ([]DiferencaScout, []DiferencaRating) {
+	difScouts := []DiferencaScout{}
+	for id, atual := range scoutsAtuais {
+		if recalculado := res.Scouts[id]; recalculado != atual {
+			difScouts = append(difScouts, DiferencaScout{JogadorID: id, Atual: atual, Recalculado: recalculado})
+		}
+	}
+	for id, recalculado := range res.Scouts {
+		if _, ok := scoutsAtuais[id]; !ok {
+			difScouts = append(difScouts, DiferencaScout{JogadorID: id, Recalculado: recalculado})
+		}
+	}
+	sort.Slice(difScouts, func(a, b int) bool { return difScouts[a].JogadorID < difScouts[b].JogadorID })
+
+	chaves := map[ChaveRating]bool{}
+	for c := range ratingsAtuais {
+		chaves[c] = true
+	}
+	for c := range res.Ratings {
+		chaves[c] = true
+	}
+	difRatings := []DiferencaRating{}
+	for c := range chaves {
+		atual, recalculado := valorRating(ratingsAtuais, c), valorRating(res.Ratings, c)
+		if int(atual.Rating) == int(recalculado.Rating) && atual.Jogos == recalculado.Jogos {
+			continue
+		}
+		difRatings = append(difRatings, DiferencaRating{
+			JogadorID:         c.JogadorID,
+			EsporteID:         c.EsporteID,
+			TipoModalidade:    c.TipoModalidade,
+			RatingAtual:       int(atual.Rating),
+			RatingRecalculado: int(recalculado.Rating),
+			JogosAtual:        atual.Jogos,
+			JogosRecalculado:  recalculado.Jogos,
+		})
+	}
+	sort.Slice(difRatings, func(a, b int) bool {
+		x, y := difRatings[a], difRatings[b]
+		if x.JogadorID != y.JogadorID {
+			return x.JogadorID < y.JogadorID
+		}
+		if x.EsporteID != y.EsporteID {
+			return x.EsporteID < y.EsporteID
+		}
+		return x.TipoModalidade < y.TipoModalidade
+	})
+	return difScouts, difRatings
+}
+
+// valorRating retorna o rating de uma chave ou o rating inicial, quando ausente.
+func valorRating(ratings map[ChaveRating]EstadoRating, c ChaveRating) EstadoRating {
+	if estado, ok := ratings[c]; ok {
+		return estado
+	}
+	return EstadoRating{Jogador: rating.NovoJogador()}
+}
@@ -0,0 +1,72 @@
+package models
+
+import "competitions/validation"
+
+// Rodada representa uma rodada de jogos, correspondendo à tabela 'rodadas'.
+// Rodadas da fase de grupos pertencem a um grupo; as demais possuem GrupoID nulo.
+type Rodada struct {
+	ID        int    `json:"id"`
+	TorneioID int    `json:"id_torneio"`
+	GrupoID   *int   `json:"id_grupo,omitempty"`
+	Numero    int    `json:"numero"`
+	Nome      string `json:"nome"`
+}
+
+// RodadaComJogos é uma estrutura para retornar uma rodada com a lista de seus jogos.
+type RodadaComJogos struct {
+	Rodada
+	Jogos []Jogo `json:"jogos"`
+}
+
+// GerarJogosInput define a categoria cujos grupos terão a tabela de jogos gerada.
+//
+//	@Description	GerarJogosInput é uma estrutura que contém a categoria para geração dos jogos dos grupos.
+type GerarJogosInput struct {
+	CategoriaID int `json:"id_categoria" validate:"required,gt=0"`
+}
+
+// Validate executa a validação na estrutura GerarJogosInput.
+func (g *GerarJogosInput) Validate() error {
+	return validation.ValidateStruct(g)
+}
+
+// GerarConfrontosRoundRobin gera as rodadas de um grupo em que todos se enfrentam
+// uma única vez, usando o método do círculo: o primeiro participante fica fixo e os
+// demais giram uma posição a cada rodada. Com uma quantidade ímpar de participantes,
+// um participante fictício (folga) é adicionado e seus confrontos são descartados,
+// de modo que em cada rodada exatamente um participante fica de folga.
+// O retorno é uma lista de rodadas, cada uma com os pares de IDs que se enfrentam.
+func GerarConfrontosRoundRobin(participantes []int) [][][2]int {
+	const folga = 0
+	ids := append([]int(nil), participantes...)
+	if len(ids)%2 != 0 {
+		ids = append(ids, folga)
+	}
+	n := len(ids)
+	if n < 2 {
+		return nil
+	}
+
+	rodadas := make([][][2]int, 0, n-1)
+	for r := 0; r < n-1; r++ {
+		var confrontos [][2]int
+		for i := 0; i < n/2; i++ {
+			a, b := ids[i], ids[n-1-i]
+			if a == folga || b == folga {
+				continue
+			}
+			// Alterna o lado do participante fixo para equilibrar quem é o "lado 1".
+			if i == 0 && r%2 == 1 {
+				a, b = b, a
+			}
+			confrontos = append(confrontos, [2]int{a, b})
+		}
+		rodadas = append(rodadas, confrontos)
+
+		// Gira todos os participantes, exceto o primeiro, uma posição no sentido horário.
+		ultimo := ids[n-1]
+		copy(ids[2:], ids[1:n-1])
+		ids[1] = ultimo
+	}
+	return rodadas
+}
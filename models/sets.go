@@ -0,0 +1,80 @@
+package models
+
+import (
+	"competitions/validation"
+	"errors"
+)
+
+// Erros de consistência do placar de um jogo.
+var (
+	ErrSetEmpatado    = errors.New("um set não pode terminar empatado")
+	ErrPlacarEmpatado = errors.New("os sets informados não definem um vencedor para o jogo")
+)
+
+// Set representa o placar de um set de um jogo, correspondendo à tabela 'sets'.
+// VencedorSet referencia o jogador vencedor e só é preenchido em jogos 'simples'.
+//
+//	@Description	Set é uma estrutura que representa o placar de um set de um jogo.
+type Set struct {
+	ID             int  `json:"id"`
+	JogoID         int  `json:"id_jogo"`
+	Numero         int  `json:"set_numero"`
+	PontosJogador1 int  `json:"pontos_jogador1"`
+	PontosJogador2 int  `json:"pontos_jogador2"`
+	VencedorSet    *int `json:"vencedor_set,omitempty"`
+}
+
+// SetInput é o placar de um set enviado pelo cliente.
+// Os pontos do lado 1 correspondem a id_jogador_torneio1/id_dupla1 do jogo.
+type SetInput struct {
+	PontosJogador1 int `json:"pontos_jogador1" validate:"gte=0"`
+	PontosJogador2 int `json:"pontos_jogador2" validate:"gte=0"`
+}
+
+// PlacarJogoInput contém a lista completa de sets de um jogo, na ordem em que foram disputados.
+//
+//	@Description	PlacarJogoInput é uma estrutura que contém a lista completa de sets de um jogo.
+type PlacarJogoInput struct {
+	Sets []SetInput `json:"sets" validate:"required,min=1,dive"`
+}
+
+// Validate executa as regras de validação na estrutura PlacarJogoInput.
+func (pi *PlacarJogoInput) Validate() error {
+	return validation.ValidateStruct(pi)
+}
+
+// PlacarJogo é a resposta com o jogo atualizado e seus sets.
+type PlacarJogo struct {
+	Jogo Jogo  `json:"jogo"`
+	Sets []Set `json:"sets"`
+}
+
+// LadoVencedorSet retorna o lado (1 ou 2) que venceu o set.
+func (s SetInput) LadoVencedorSet() (int, error) {
+	switch {
+	case s.PontosJogador1 > s.PontosJogador2:
+		return 1, nil
+	case s.PontosJogador2 > s.PontosJogador1:
+		return 2, nil
+	}
+	return 0, ErrSetEmpatado
+}
+
+// LadoVencedorJogo deriva o lado vencedor do jogo a partir da quantidade de sets ganhos.
+func LadoVencedorJogo(sets []SetInput) (int, error) {
+	var ganhos [3]int
+	for _, s := range sets {
+		lado, err := s.LadoVencedorSet()
+		if err != nil {
+			return 0, err
+		}
+		ganhos[lado]++
+	}
+	switch {
+	case ganhos[1] > ganhos[2]:
+		return 1, nil
+	case ganhos[2] > ganhos[1]:
+		return 2, nil
+	}
+	return 0, ErrPlacarEmpatado
+}
@@ -43,13 +43,19 @@ import (
 type Torneio struct {
 	ID         int       `json:"id,omitempty" db:"id"`
 	Nome       string    `json:"nome" db:"nome"`
-	DataInicio time.Time `json:"data_inicio" db:"data_inicio"`
-	DataFim    time.Time `json:"data_fim" db:"data_fim"`
+	DataInicio time.Time `json:"data_inicio" db:"inicio"`
+	DataFim    time.Time `json:"data_fim" db:"fim"`
 	EsporteID  int       `json:"id_esporte" db:"id_esporte"`
 	CidadeID   int       `json:"id_cidade" db:"id_cidade"`
 	EstadoID   int       `json:"id_estado" db:"id_estado"`
 	PaisID     int       `json:"id_pais" db:"id_pais"`
 	CriadoEm   time.Time `json:"criado_em,omitempty" db:"criado_em"`
+	// Quantidade de quadras disponíveis para o agendamento simultâneo de jogos.
+	QuantidadeQuadras int `json:"quantidade_quadras" db:"quantidade_quadras"`
+	// Ordem dos critérios de desempate da fase de grupos.
+	CriteriosDesempate []string `json:"criterios_desempate" db:"criterios_desempate"`
+	// Usuário que criou o torneio; nulo em torneios anteriores ao registro do criador.
+	CriadorID *int `json:"id_usuario_criador,omitempty" db:"id_usuario_criador"`
 }
 
 // TorneioInput é usado para receber dados de entrada ao criar ou atualizar um torneio.
@@ -88,9 +94,35 @@ type TorneioInput struct {
 	CidadeID   int       `json:"id_cidade" validate:"required,gt=0"`
 	EstadoID   int       `json:"id_estado" validate:"required,gt=0"`
 	PaisID     int       `json:"id_pais" validate:"required,gt=0"`
+	// Quantidade de quadras disponíveis. Se omitida, o torneio é criado com 1 quadra.
+	QuantidadeQuadras int `json:"quantidade_quadras" validate:"omitempty,gte=1"`
+	// Ordem dos critérios de desempate da fase de grupos. Se omitido, usa CriteriosDesempatePadrao.
+	CriteriosDesempate []string `json:"criterios_desempate" validate:"omitempty,unique,dive,oneof=vitorias confronto_direto razao_sets razao_pontos"`
 }
 
 // Validação usando go-playground/validator
 func (t *TorneioInput) Validate() error {
 	return validation.ValidateStruct(t)
 }
+
+// OrganizadorTorneio é um usuário que pode alterar um torneio: o criador ou um coorganizador.
+//
+//	@Description	OrganizadorTorneio é uma estrutura que representa um organizador de um torneio.
+type OrganizadorTorneio struct {
+	UsuarioID int    `json:"id_usuario"`
+	Nome      string `json:"nome"`
+	Username  string `json:"username"`
+	Criador   bool   `json:"criador"`
+}
+
+// OrganizadorInput é usado para adicionar um coorganizador a um torneio.
+//
+//	@Description	OrganizadorInput é uma estrutura que contém o usuário a ser adicionado como coorganizador.
+type OrganizadorInput struct {
+	UsuarioID int `json:"id_usuario" validate:"required,gt=0"`
+}
+
+// Validate executa a validação na estrutura OrganizadorInput.
+func (o *OrganizadorInput) Validate() error {
+	return validation.ValidateStruct(o)
+}
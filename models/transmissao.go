@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tipos de evento da transmissão ao vivo de um torneio.
+const (
+	TransmissaoPlacar    = "placar"    // Placar ao vivo alterado (dados: PlacarAoVivo)
+	TransmissaoResultado = "resultado" // Sets gravados e jogo encerrado (dados: PlacarJogo)
+	TransmissaoSituacao  = "situacao"  // Situação do jogo alterada
+	TransmissaoQuadra    = "quadra"    // Quadra ou horário do jogo alterado
+)
+
+// ErrPosicaoTransmissaoInvalida é retornado quando o Last-Event-ID recebido não é uma posição válida.
+var ErrPosicaoTransmissaoInvalida = errors.New("o identificador do último evento recebido é inválido")
+
+// PosicaoTransmissao é a posição de um evento na transmissão de um torneio: a transação que o
+// gravou e o seu ID. É enviada como ID do evento SSE no formato "transacao-id".
+type PosicaoTransmissao struct {
+	Transacao int64
+	Evento    int64
+}
+
+// String formata a posição como ID de evento SSE.
+func (p PosicaoTransmissao) String() string {
+	return fmt.Sprintf("%d-%d", p.Transacao, p.Evento)
+}
+
+// ParsePosicaoTransmissao lê uma posição no formato "transacao-id".
+func ParsePosicaoTransmissao(s string) (PosicaoTransmissao, error) {
+	transacao, evento, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return PosicaoTransmissao{}, ErrPosicaoTransmissaoInvalida
+	}
+	var p PosicaoTransmissao
+	var err1, err2 error
+	p.Transacao, err1 = strconv.ParseInt(transacao, 10, 64)
+	p.Evento, err2 = strconv.ParseInt(evento, 10, 64)
+	if err1 != nil || err2 != nil || p.Transacao < 0 || p.Evento < 0 {
+		return PosicaoTransmissao{}, ErrPosicaoTransmissaoInvalida
+	}
+	return p, nil
+}
+
+// EventoTransmissao é um evento da transmissão ao vivo de um torneio, correspondendo à
+// tabela 'eventos_transmissao'. O conteúdo de Dados depende do tipo do evento.
+//
+//	@Description	EventoTransmissao é uma estrutura que representa um evento da transmissão ao vivo de um torneio.
+type EventoTransmissao struct {
+	ID             string             `json:"id"` // Posição do evento, usada como Last-Event-ID
+	Tipo           string             `json:"tipo"`
+	TorneioID      int                `json:"id_torneio"`
+	JogoID         *int               `json:"id_jogo,omitempty"`
+	Quadra         *string            `json:"quadra,omitempty"`
+	QuadraAnterior *string            `json:"quadra_anterior,omitempty"`
+	Dados          json.RawMessage    `json:"dados" swaggertype:"object"`
+	CriadoEm       time.Time          `json:"criado_em"`
+	Posicao        PosicaoTransmissao `json:"-"`
+}
+
+// FiltroTransmissao restringe os eventos de um torneio a um jogo e/ou a uma quadra. Eventos
+// de mudança de quadra também são entregues aos inscritos na quadra de onde o jogo saiu.
+type FiltroTransmissao struct {
+	TorneioID int
+	JogoID    int
+	Quadra    string
+	Apos      PosicaoTransmissao
+	Limite    int
+}
@@ -5,6 +5,15 @@ import (
 	"time"
 )
 
+// Tipos de usuário (enum tipo_usuario), que definem as permissões de acesso.
+const (
+	TipoJogador       = "jogador"
+	TipoUsuario       = "usuario"
+	TipoAdmin         = "admin"
+	TipoGestorClube   = "gestor_clube"
+	TipoGestorTorneio = "gestor_torneio"
+)
+
 // Usuario representa um usuário do sistema, com diferentes tipos de acesso e informações pessoais.
 // Os tipos de usuário são: jogador, usuario, admin, gestor_clube e gestor_torneio.
 // A tabela é criada com o nome "usuarios" e possui os seguintes campos:
@@ -30,6 +39,7 @@ type Usuario struct {
 	Instagram      string    `json:"instagram,omitempty"`
 	CriadoEm       time.Time `json:"criado_em,omitempty"`
 	Ativo          bool      `json:"ativo"`
+	VersaoToken    int       `json:"-" db:"-"` // Versão dos tokens do usuário, lida apenas na autenticação
 }
 
 // UsuarioInput é usado para receber dados de entrada ao criar um usuário.
@@ -11,7 +11,8 @@ type EstatisticasJogador struct {
 // VencedorGrupo representa um vencedor de um grupo.
 type VencedorGrupo struct {
 	Posicao      int    `json:"posicao"`
-	JogadorID    int    `json:"id_jogador"`
+	InscricaoID  int    `json:"id_inscricao"`
+	JogadorID    int    `json:"id_jogador,omitempty"`
 	NomeJogador  string `json:"nome_jogador"`
 	Criterio     string `json:"criterio"`
 	SetsGanhos   int    `json:"sets_ganhos,omitempty"`
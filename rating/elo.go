@@ -0,0 +1,16 @@
+package rating
+
+import "math"
+
+// EsperadoElo retorna a pontuação esperada (probabilidade de vitória) de um jogador
+// com rating ra contra um adversário com rating rb.
+func EsperadoElo(ra, rb float64) float64 {
+	return 1 / (1 + math.Pow(10, (rb-ra)/400))
+}
+
+// DeltaElo retorna a variação de rating do jogador com rating ra após enfrentar um
+// adversário com rating rb, onde pontuacao é 1 para vitória, 0,5 para empate e 0
+// para derrota. A variação do adversário é a mesma, com o sinal invertido.
+func DeltaElo(ra, rb, pontuacao, k float64) float64 {
+	return k * (pontuacao - EsperadoElo(ra, rb))
+}
@@ -0,0 +1,93 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEsperadoElo(t *testing.T) {
+	casos := []struct {
+		ra, rb   float64
+		esperado float64
+	}{
+		{1500, 1500, 0.5},
+		{1600, 1500, 1 / (1 + math.Pow(10, -0.25))},
+		{1900, 1500, 10.0 / 11},
+		{1500, 1900, 1.0 / 11},
+	}
+	for _, c := range casos {
+		if got := EsperadoElo(c.ra, c.rb); math.Abs(got-c.esperado) > 1e-9 {
+			t.Errorf("EsperadoElo(%v, %v) = %.6f, esperado %.6f", c.ra, c.rb, got, c.esperado)
+		}
+	}
+}
+
+func TestEsperadoEloSimetrico(t *testing.T) {
+	for _, par := range [][2]float64{{1500, 1500}, {1620, 1480}, {1200, 2100}} {
+		if soma := EsperadoElo(par[0], par[1]) + EsperadoElo(par[1], par[0]); math.Abs(soma-1) > 1e-9 {
+			t.Errorf("EsperadoElo(%v) + inverso = %.9f, esperado 1", par, soma)
+		}
+	}
+}
+
+func TestDeltaElo(t *testing.T) {
+	casos := []struct {
+		nome                 string
+		ra, rb, pontuacao, k float64
+		esperado             float64
+	}{
+		{"vitória entre iguais", 1500, 1500, 1, 32, 16},
+		{"derrota entre iguais", 1500, 1500, 0, 32, -16},
+		{"empate entre iguais", 1500, 1500, 0.5, 32, 0},
+		{"vitória do favorito", 1900, 1500, 1, 32, 32.0 / 11},
+		{"vitória do azarão", 1500, 1900, 1, 32, 320.0 / 11},
+		{"fator K maior", 1500, 1500, 1, 40, 20},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if got := DeltaElo(c.ra, c.rb, c.pontuacao, c.k); math.Abs(got-c.esperado) > 1e-9 {
+				t.Errorf("DeltaElo = %.6f, esperado %.6f", got, c.esperado)
+			}
+		})
+	}
+}
+
+func TestDeltaEloSimetrico(t *testing.T) {
+	ra, rb := 1620.0, 1480.0
+	ganho := DeltaElo(ra, rb, 1, FatorKPadrao)
+	perda := DeltaElo(rb, ra, 0, FatorKPadrao)
+	if math.Abs(ganho+perda) > 1e-9 {
+		t.Errorf("ganho do vencedor (%.6f) e perda do perdedor (%.6f) deveriam se anular", ganho, perda)
+	}
+}
+
+func TestAtualizarPartidaElo(t *testing.T) {
+	cfg := Configuracao{Algoritmo: AlgoritmoElo, FatorK: 32}
+	vencedores := []Jogador{{Rating: 1600}, {Rating: 1400}}
+	perdedores := []Jogador{{Rating: 1500}, {Rating: 1500}}
+
+	novosVencedores, novosPerdedores, err := cfg.AtualizarPartida(vencedores, perdedores)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	// Em duplas, a média dos lados é 1500 x 1500: cada jogador ganha ou perde 16 pontos.
+	for i, j := range novosVencedores {
+		if math.Abs(j.Rating-vencedores[i].Rating-16) > 1e-9 {
+			t.Errorf("vencedor %d: rating = %.2f", i, j.Rating)
+		}
+	}
+	for i, j := range novosPerdedores {
+		if math.Abs(j.Rating-perdedores[i].Rating+16) > 1e-9 {
+			t.Errorf("perdedor %d: rating = %.2f", i, j.Rating)
+		}
+	}
+}
+
+func TestAtualizarPartidaInvalida(t *testing.T) {
+	if _, _, err := (Configuracao{Algoritmo: "trueskill"}).AtualizarPartida([]Jogador{NovoJogador()}, []Jogador{NovoJogador()}); err == nil {
+		t.Error("esperado erro para algoritmo desconhecido")
+	}
+	if _, _, err := ConfiguracaoPadrao().AtualizarPartida(nil, []Jogador{NovoJogador()}); err == nil {
+		t.Error("esperado erro para lado sem jogadores")
+	}
+}
@@ -0,0 +1,91 @@
+package rating
+
+import "math"
+
+// escalaGlicko2 converte ratings e desvios da escala Glicko para a escala interna do Glicko-2.
+const escalaGlicko2 = 173.7178
+
+// toleranciaGlicko2 é a precisão usada no cálculo iterativo da nova volatilidade.
+const toleranciaGlicko2 = 0.000001
+
+// Resultado é o desfecho de um jogo do ponto de vista de um jogador.
+type Resultado struct {
+	Adversario Jogador
+	Pontuacao  float64 // 1 para vitória, 0,5 para empate e 0 para derrota.
+}
+
+// AtualizarGlicko2 aplica um período de rating do Glicko-2 (Glickman, 2012) ao jogador
+// com os resultados informados. Sem resultados, apenas o desvio aumenta.
+func AtualizarGlicko2(j Jogador, resultados []Resultado, tau float64) Jogador {
+	mu := (j.Rating - RatingInicial) / escalaGlicko2
+	phi := j.Desvio / escalaGlicko2
+	sigma := j.Volatilidade
+
+	if len(resultados) == 0 {
+		phiEstrela := math.Sqrt(phi*phi + sigma*sigma)
+		return Jogador{Rating: j.Rating, Desvio: phiEstrela * escalaGlicko2, Volatilidade: sigma}
+	}
+
+	// Variância estimada (v) e melhoria estimada (delta) a partir dos resultados.
+	var somaV, somaDelta float64
+	for _, r := range resultados {
+		muAdv := (r.Adversario.Rating - RatingInicial) / escalaGlicko2
+		gAdv := g(r.Adversario.Desvio / escalaGlicko2)
+		e := 1 / (1 + math.Exp(-gAdv*(mu-muAdv)))
+		somaV += gAdv * gAdv * e * (1 - e)
+		somaDelta += gAdv * (r.Pontuacao - e)
+	}
+	v := 1 / somaV
+	delta := v * somaDelta
+
+	novaSigma := novaVolatilidade(phi, sigma, v, delta, tau)
+	phiEstrela := math.Sqrt(phi*phi + novaSigma*novaSigma)
+	novoPhi := 1 / math.Sqrt(1/(phiEstrela*phiEstrela)+1/v)
+	novoMu := mu + novoPhi*novoPhi*somaDelta
+
+	return Jogador{
+		Rating:       novoMu*escalaGlicko2 + RatingInicial,
+		Desvio:       novoPhi * escalaGlicko2,
+		Volatilidade: novaSigma,
+	}
+}
+
+// g reduz o impacto de um resultado de acordo com a incerteza do rating do adversário.
+func g(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// novaVolatilidade resolve a equação da volatilidade pelo método de Illinois (regula falsi).
+func novaVolatilidade(phi, sigma, v, delta, tau float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > toleranciaGlicko2 {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	return math.Exp(A / 2)
+}
@@ -0,0 +1,64 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+// TestAtualizarGlicko2ExemploGlickman reproduz o exemplo do artigo "Example of the
+// Glicko-2 system" (Glickman, 2012): um jogador 1500/200/0,06 que vence um adversário
+// 1400/30 e perde para 1550/100 e 1700/300, com tau = 0,5.
+func TestAtualizarGlicko2ExemploGlickman(t *testing.T) {
+	jogador := Jogador{Rating: 1500, Desvio: 200, Volatilidade: 0.06}
+	resultados := []Resultado{
+		{Adversario: Jogador{Rating: 1400, Desvio: 30}, Pontuacao: 1},
+		{Adversario: Jogador{Rating: 1550, Desvio: 100}, Pontuacao: 0},
+		{Adversario: Jogador{Rating: 1700, Desvio: 300}, Pontuacao: 0},
+	}
+
+	novo := AtualizarGlicko2(jogador, resultados, 0.5)
+
+	if math.Abs(novo.Rating-1464.06) > 0.01 {
+		t.Errorf("Rating = %.4f, esperado ≈ 1464.06", novo.Rating)
+	}
+	if math.Abs(novo.Desvio-151.52) > 0.01 {
+		t.Errorf("Desvio = %.4f, esperado ≈ 151.52", novo.Desvio)
+	}
+	if math.Abs(novo.Volatilidade-0.05999) > 0.00001 {
+		t.Errorf("Volatilidade = %.6f, esperado ≈ 0.05999", novo.Volatilidade)
+	}
+}
+
+func TestAtualizarGlicko2SemResultados(t *testing.T) {
+	jogador := Jogador{Rating: 1500, Desvio: 200, Volatilidade: 0.06}
+
+	novo := AtualizarGlicko2(jogador, nil, 0.5)
+
+	if novo.Rating != jogador.Rating || novo.Volatilidade != jogador.Volatilidade {
+		t.Errorf("rating e volatilidade não deveriam mudar: %+v", novo)
+	}
+	// phi* = sqrt(phi² + sigma²), na escala do Glicko-2.
+	esperado := math.Sqrt(math.Pow(200/escalaGlicko2, 2)+0.06*0.06) * escalaGlicko2
+	if math.Abs(novo.Desvio-esperado) > 1e-9 {
+		t.Errorf("Desvio = %.4f, esperado %.4f", novo.Desvio, esperado)
+	}
+	if novo.Desvio <= jogador.Desvio {
+		t.Errorf("Desvio deveria aumentar sem resultados: %.4f", novo.Desvio)
+	}
+}
+
+func TestAtualizarPartidaGlicko2(t *testing.T) {
+	cfg := Configuracao{Algoritmo: AlgoritmoGlicko2, Tau: 0.5}
+
+	vencedores, perdedores, err := cfg.AtualizarPartida([]Jogador{NovoJogador()}, []Jogador{NovoJogador()})
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if vencedores[0].Rating <= RatingInicial || perdedores[0].Rating >= RatingInicial {
+		t.Errorf("vencedor = %.2f, perdedor = %.2f", vencedores[0].Rating, perdedores[0].Rating)
+	}
+	// Jogadores iguais: as variações são simétricas.
+	if d := (vencedores[0].Rating - RatingInicial) + (perdedores[0].Rating - RatingInicial); math.Abs(d) > 1e-9 {
+		t.Errorf("variações não simétricas: soma = %g", d)
+	}
+}
@@ -0,0 +1,105 @@
+// Package rating implementa os algoritmos que calculam o novo rating dos jogadores a cada
+// resultado: Elo, com fator K fixo, e Glicko-2, que também acompanha o desvio (incerteza) e
+// a volatilidade do rating de cada jogador. Os ratings são mantidos por esporte e modalidade
+// em ratings_jogadores, separados dos scouts.
+package rating
+
+import "fmt"
+
+// Algoritmos de rating suportados, selecionados por esporte.
+const (
+	AlgoritmoElo     = "elo"
+	AlgoritmoGlicko2 = "glicko2"
+)
+
+// Valores iniciais de um jogador sem histórico e parâmetros padrão dos algoritmos.
+const (
+	RatingInicial       = 1500.0
+	DesvioInicial       = 350.0
+	VolatilidadeInicial = 0.06
+	FatorKPadrao        = 32.0
+	TauPadrao           = 0.5
+)
+
+// Jogador é o estado de rating de um jogador. Desvio e Volatilidade só são usados pelo Glicko-2.
+type Jogador struct {
+	Rating       float64
+	Desvio       float64
+	Volatilidade float64
+}
+
+// NovoJogador retorna o estado de rating de um jogador sem histórico.
+func NovoJogador() Jogador {
+	return Jogador{Rating: RatingInicial, Desvio: DesvioInicial, Volatilidade: VolatilidadeInicial}
+}
+
+// Configuracao define o algoritmo e os parâmetros de rating de um esporte.
+type Configuracao struct {
+	Algoritmo string
+	FatorK    float64 // Elo: variação máxima de rating em um jogo.
+	Tau       float64 // Glicko-2: restringe a variação da volatilidade ao longo do tempo.
+}
+
+// ConfiguracaoPadrao retorna a configuração usada quando o esporte não define outra.
+func ConfiguracaoPadrao() Configuracao {
+	return Configuracao{Algoritmo: AlgoritmoElo, FatorK: FatorKPadrao, Tau: TauPadrao}
+}
+
+// AtualizarPartida calcula os novos ratings dos jogadores de um jogo encerrado. Em
+// simples cada lado tem um jogador; em duplas, cada lado é representado pela média
+// dos ratings (e desvios) dos seus jogadores, e cada jogador é atualizado contra o
+// lado adversário.
+func (c Configuracao) AtualizarPartida(vencedores, perdedores []Jogador) ([]Jogador, []Jogador, error) {
+	if len(vencedores) == 0 || len(perdedores) == 0 {
+		return nil, nil, fmt.Errorf("os dois lados do jogo precisam ter jogadores")
+	}
+
+	ladoVencedor, ladoPerdedor := media(vencedores), media(perdedores)
+	switch c.Algoritmo {
+	case AlgoritmoElo, "":
+		k := c.FatorK
+		if k <= 0 {
+			k = FatorKPadrao
+		}
+		delta := DeltaElo(ladoVencedor.Rating, ladoPerdedor.Rating, 1, k)
+		return somarRating(vencedores, delta), somarRating(perdedores, -delta), nil
+	case AlgoritmoGlicko2:
+		tau := c.Tau
+		if tau <= 0 {
+			tau = TauPadrao
+		}
+		novosVencedores := make([]Jogador, len(vencedores))
+		for i, j := range vencedores {
+			novosVencedores[i] = AtualizarGlicko2(j, []Resultado{{Adversario: ladoPerdedor, Pontuacao: 1}}, tau)
+		}
+		novosPerdedores := make([]Jogador, len(perdedores))
+		for i, j := range perdedores {
+			novosPerdedores[i] = AtualizarGlicko2(j, []Resultado{{Adversario: ladoVencedor, Pontuacao: 0}}, tau)
+		}
+		return novosVencedores, novosPerdedores, nil
+	default:
+		return nil, nil, fmt.Errorf("algoritmo de rating desconhecido: '%s'", c.Algoritmo)
+	}
+}
+
+// media retorna um jogador fictício com a média dos ratings, desvios e volatilidades.
+func media(jogadores []Jogador) Jogador {
+	var m Jogador
+	for _, j := range jogadores {
+		m.Rating += j.Rating
+		m.Desvio += j.Desvio
+		m.Volatilidade += j.Volatilidade
+	}
+	n := float64(len(jogadores))
+	return Jogador{Rating: m.Rating / n, Desvio: m.Desvio / n, Volatilidade: m.Volatilidade / n}
+}
+
+// somarRating retorna uma cópia dos jogadores com o delta aplicado ao rating.
+func somarRating(jogadores []Jogador, delta float64) []Jogador {
+	novos := make([]Jogador, len(jogadores))
+	for i, j := range jogadores {
+		j.Rating += delta
+		novos[i] = j
+	}
+	return novos
+}
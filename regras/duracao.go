@@ -0,0 +1,50 @@
+package regras
+
+import (
+	"math"
+	"time"
+)
+
+// Fatores médios entre os pontos (ou games) disputados e os necessários para vencer um
+// set ou game, usados apenas para estimar a duração das partidas.
+const (
+	fatorDisputa            = 1.6
+	fatorDisputaSemVantagem = 1.4
+)
+
+// pontosSet estima os pontos disputados em um set da regra informada.
+func (r Regra) pontosSet(rs RegraSet) float64 {
+	if !r.ContaGames() {
+		return float64(rs.Alvo) * fatorDisputa
+	}
+	fatorGame := fatorDisputa
+	if r.Game.Diferenca < 2 {
+		fatorGame = fatorDisputaSemVantagem
+	}
+	return float64(rs.Alvo) * fatorDisputa * float64(r.Game.Alvo) * fatorGame
+}
+
+// PontosEstimados estima a quantidade de pontos disputados em uma partida. A partida tem,
+// em média, os sets necessários para vencer mais metade dos sets restantes; quando há
+// set decisivo, essa metade é disputada no formato do set decisivo.
+func (r Regra) PontosEstimados() float64 {
+	para := r.SetsParaVencer()
+	esperado := float64(para) + float64(r.MelhorDe-para)/2
+	set := r.pontosSet(r.Set)
+	if r.SetDecisivo == nil || r.MelhorDe < 2 {
+		return esperado * set
+	}
+	decisivo := float64(r.SetDecisivo.Alvo) * fatorDisputa
+	return (esperado-0.5)*set + 0.5*decisivo
+}
+
+// DuracaoEstimada estima a duração de uma partida desta regra proporcionalmente à duração
+// conhecida de uma partida no formato padrão do esporte, arredondada para minutos.
+func (r Regra) DuracaoEstimada(padrao Regra, duracaoPadrao time.Duration) time.Duration {
+	base := padrao.PontosEstimados()
+	if base <= 0 {
+		return duracaoPadrao
+	}
+	minutos := math.Round(duracaoPadrao.Minutes() * r.PontosEstimados() / base)
+	return time.Duration(math.Max(minutos, 1)) * time.Minute
+}
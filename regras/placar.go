@@ -0,0 +1,169 @@
+package regras
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Tipos de evento da pontuação ao vivo.
+const (
+	EventoPonto = "ponto" // Ponto vencido pelo lado informado.
+	EventoFalta = "falta" // Falta cometida pelo lado informado.
+	EventoLet   = "let"   // Ponto repetido; não altera o placar.
+)
+
+// Erros da pontuação ao vivo.
+var (
+	ErrEventoInvalido   = errors.New("evento de pontuação inválido")
+	ErrPartidaEncerrada = errors.New("a partida já está decidida; não é possível registrar novos pontos")
+)
+
+// Evento é um evento da pontuação ao vivo de uma partida.
+type Evento struct {
+	Tipo string
+	Lado int // 1 ou 2; ignorado em EventoLet.
+}
+
+// Estado é o placar de uma partida em andamento, calculado a partir dos seus eventos.
+// Nos esportes contados em games, Games é o placar de games do set em andamento e Pontos
+// o do game (ou tiebreak) em andamento; nos demais esportes e no super tiebreak decisivo,
+// Pontos é o placar do próprio set e Games fica zerado.
+type Estado struct {
+	Sets     []Set
+	Games    Set
+	Pontos   Set
+	Tiebreak bool // O game em andamento é um tiebreak ou o super tiebreak decisivo.
+	Vencedor int  // Lado vencedor da partida; zero enquanto ela não está decidida.
+
+	faltas [3]int // Faltas consecutivas de cada lado desde o último ponto.
+}
+
+// NovoEstado retorna o placar de uma partida que ainda não começou.
+func (r Regra) NovoEstado() Estado {
+	return Estado{Sets: []Set{}}
+}
+
+// Reproduzir calcula o placar de uma partida aplicando os eventos em ordem.
+func (r Regra) Reproduzir(eventos []Evento) (Estado, error) {
+	e := r.NovoEstado()
+	for i, ev := range eventos {
+		if err := r.Registrar(&e, ev); err != nil {
+			return e, fmt.Errorf("evento %d: %w", i+1, err)
+		}
+	}
+	return e, nil
+}
+
+// Registrar aplica um evento ao placar. Nos esportes contados em games, a falta é uma falta
+// de saque: duas faltas seguidas do mesmo lado (dupla falta) dão o ponto ao adversário, e o
+// let repete o saque sem anular a primeira falta. Nos demais esportes, toda falta dá o
+// ponto ao adversário.
+func (r Regra) Registrar(e *Estado, ev Evento) error {
+	if ev.Tipo != EventoLet && ev.Lado != 1 && ev.Lado != 2 {
+		return fmt.Errorf("%w: o lado deve ser 1 ou 2", ErrEventoInvalido)
+	}
+	if e.Vencedor != 0 {
+		return ErrPartidaEncerrada
+	}
+
+	switch ev.Tipo {
+	case EventoLet:
+		return nil
+	case EventoFalta:
+		e.faltas[ev.Lado]++
+		if r.ContaGames() && e.faltas[ev.Lado] < 2 {
+			return nil
+		}
+		r.ponto(e, 3-ev.Lado)
+		return nil
+	case EventoPonto:
+		r.ponto(e, ev.Lado)
+		return nil
+	}
+	return fmt.Errorf("%w: tipo '%s' desconhecido", ErrEventoInvalido, ev.Tipo)
+}
+
+// setDecisivoAtual indica se o set em andamento é disputado como super tiebreak.
+func (r Regra) setDecisivoAtual(e *Estado) bool {
+	return r.SetDecisivo != nil && r.MelhorDe > 1 && len(e.Sets)+1 == r.MelhorDe
+}
+
+// ponto soma um ponto ao lado informado e fecha o game, o set e a partida quando for o caso.
+func (r Regra) ponto(e *Estado, lado int) {
+	e.faltas = [3]int{}
+	somar(&e.Pontos, lado)
+
+	switch {
+	case r.setDecisivoAtual(e):
+		if r.SetDecisivo.vencedor(e.Pontos) != 0 {
+			r.fecharSet(e, e.Pontos)
+		}
+	case !r.ContaGames():
+		if r.Set.vencedor(e.Pontos) != 0 {
+			r.fecharSet(e, e.Pontos)
+		}
+	default:
+		regraGame := *r.Game
+		if e.Tiebreak {
+			regraGame = *r.Tiebreak
+		}
+		if regraGame.vencedor(e.Pontos) == 0 {
+			return
+		}
+		e.Pontos = Set{}
+		somar(&e.Games, lado)
+		if r.Set.vencedor(e.Games) != 0 {
+			r.fecharSet(e, e.Games)
+			return
+		}
+		e.Tiebreak = r.Tiebreak != nil && e.Games.Lado1 == r.Set.Alvo && e.Games.Lado2 == r.Set.Alvo
+	}
+}
+
+// fecharSet registra o set encerrado e verifica se a partida foi decidida.
+func (r Regra) fecharSet(e *Estado, placar Set) {
+	e.Sets = append(e.Sets, placar)
+	e.Games, e.Pontos = Set{}, Set{}
+	e.Tiebreak = r.setDecisivoAtual(e)
+
+	var ganhos [3]int
+	for _, s := range e.Sets {
+		if s.Lado1 > s.Lado2 {
+			ganhos[1]++
+		} else {
+			ganhos[2]++
+		}
+	}
+	for lado := 1; lado <= 2; lado++ {
+		if ganhos[lado] == r.SetsParaVencer() {
+			e.Vencedor = lado
+			e.Tiebreak = false
+		}
+	}
+}
+
+// vencedor retorna o lado que venceu o set ou game com o placar informado, ou zero se a
+// disputa ainda não terminou.
+func (rs RegraSet) vencedor(s Set) int {
+	lado, v, p := 1, s.Lado1, s.Lado2
+	if s.Lado2 > s.Lado1 {
+		lado, v, p = 2, s.Lado2, s.Lado1
+	}
+	diferenca := rs.Diferenca
+	if diferenca < 1 {
+		diferenca = 1
+	}
+	if (v >= rs.Alvo && v-p >= diferenca) || (rs.Limite > 0 && v >= rs.Limite) {
+		return lado
+	}
+	return 0
+}
+
+// somar adiciona um ponto (ou game) ao lado informado.
+func somar(s *Set, lado int) {
+	if lado == 1 {
+		s.Lado1++
+	} else {
+		s.Lado2++
+	}
+}
@@ -0,0 +1,251 @@
+// Package regras implementa as regras de pontuação de cada esporte usadas para validar
+// o placar de um jogo antes de aceitá-lo: o placar de cada set (games ou pontos
+// necessários, vitória por dois de diferença, tiebreak e limite de pontos), o set
+// decisivo disputado como super tiebreak e o formato da partida em melhor de N sets.
+package regras
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Erros de validação do placar de um jogo.
+var (
+	ErrSetInvalido          = errors.New("placar de set inválido para as regras do esporte")
+	ErrSetsInsuficientes    = errors.New("os sets informados não completam a partida")
+	ErrSetsAposDecisao      = errors.New("foram informados sets depois de a partida já estar decidida")
+	ErrQuantidadeSetsMaxima = errors.New("a quantidade de sets excede o formato da partida")
+)
+
+// Esportes com regras de pontuação definidas, com os nomes do enum esporte_enum.
+const (
+	Tenis       = "Tenis"
+	TenisDeMesa = "Tenis de Mesa"
+	Badminton   = "Badminton"
+	Padel       = "Padel"
+	BeachTenis  = "Beach Tenis"
+	Pickleball  = "Pickleball"
+	Squash      = "Squash"
+)
+
+// Set é o placar de um set: games (tênis, padel, beach tênis) ou pontos (demais esportes)
+// de cada lado.
+type Set struct {
+	Lado1 int
+	Lado2 int
+}
+
+// RegraSet define como um set é vencido. O vencedor precisa alcançar Alvo com pelo menos
+// Diferenca de vantagem; com o placar empatado a partir de Alvo-1, a disputa segue até
+// alguém abrir a diferença. Limite, quando maior que zero, é o placar máximo do vencedor,
+// alcançado com um de vantagem: em sets com tiebreak, o set termina em 7 x 6 (Alvo+1);
+// no badminton, em 30 x 29.
+type RegraSet struct {
+	Alvo      int
+	Diferenca int
+	Limite    int
+	Unidade   string // "games" ou "pontos", usada nas mensagens de erro
+}
+
+// Regra define o formato de uma partida de um esporte. SetDecisivo, quando definido,
+// substitui o último set possível da partida (ex.: super tiebreak até 10 no lugar do
+// terceiro set). Nos esportes contados em games, Game define os pontos de um game e
+// Tiebreak o tiebreak disputado no empate em games (nulo quando o set não tem tiebreak);
+// nos demais esportes, cada set é um game disputado em pontos e ambos são nulos.
+type Regra struct {
+	Esporte     string
+	MelhorDe    int
+	Set         RegraSet
+	SetDecisivo *RegraSet
+	Game        *RegraSet
+	Tiebreak    *RegraSet
+}
+
+// Formato reúne as opções de formato de partida configuráveis por torneio e categoria.
+// Campos nulos mantêm o valor da regra à qual o formato é aplicado.
+type Formato struct {
+	MelhorDe              *int
+	GamesPorSet           *int
+	PontosPorGame         *int
+	SemVantagem           *bool
+	TiebreakEmpate        *bool
+	SuperTiebreakDecisivo *bool
+}
+
+// Sets com games do tênis e esportes derivados: 6 games com 2 de diferença e tiebreak em 6 x 6.
+var setComTiebreak = RegraSet{Alvo: 6, Diferenca: 2, Limite: 7, Unidade: "games"}
+
+// GameComVantagem é o game do tênis: 4 pontos (15, 30, 40, game) com 2 de diferença.
+var GameComVantagem = RegraSet{Alvo: 4, Diferenca: 2, Unidade: "pontos"}
+
+// TiebreakPadrao é o tiebreak disputado no empate em games: 7 pontos com 2 de diferença.
+var TiebreakPadrao = RegraSet{Alvo: 7, Diferenca: 2, Unidade: "pontos"}
+
+// SuperTiebreak é o set decisivo disputado como um tiebreak até 10 pontos com 2 de diferença.
+var SuperTiebreak = RegraSet{Alvo: 10, Diferenca: 2, Unidade: "pontos"}
+
+// regrasPadrao são as regras oficiais usadas quando o torneio não define outro formato.
+var regrasPadrao = map[string]Regra{
+	Tenis:       {Esporte: Tenis, MelhorDe: 3, Set: setComTiebreak, Game: &GameComVantagem, Tiebreak: &TiebreakPadrao},
+	Padel:       {Esporte: Padel, MelhorDe: 3, Set: setComTiebreak, Game: &GameComVantagem, Tiebreak: &TiebreakPadrao},
+	BeachTenis:  {Esporte: BeachTenis, MelhorDe: 3, Set: setComTiebreak, SetDecisivo: &SuperTiebreak, Game: &GameComVantagem, Tiebreak: &TiebreakPadrao},
+	TenisDeMesa: {Esporte: TenisDeMesa, MelhorDe: 5, Set: RegraSet{Alvo: 11, Diferenca: 2, Unidade: "pontos"}},
+	Badminton:   {Esporte: Badminton, MelhorDe: 3, Set: RegraSet{Alvo: 21, Diferenca: 2, Limite: 30, Unidade: "pontos"}},
+	Pickleball:  {Esporte: Pickleball, MelhorDe: 3, Set: RegraSet{Alvo: 11, Diferenca: 2, Unidade: "pontos"}},
+	Squash:      {Esporte: Squash, MelhorDe: 5, Set: RegraSet{Alvo: 11, Diferenca: 2, Unidade: "pontos"}},
+}
+
+// DoEsporte retorna as regras padrão de um esporte. O segundo retorno é falso quando o
+// esporte não possui regras definidas.
+func DoEsporte(esporte string) (Regra, bool) {
+	r, ok := regrasPadrao[esporte]
+	return r, ok
+}
+
+// ContaGames indica se os sets do esporte são contados em games.
+func (r Regra) ContaGames() bool {
+	return r.Game != nil
+}
+
+// Aplicar retorna a regra com as opções do formato informado. Nos esportes contados em
+// games, GamesPorSet altera os games do set, PontosPorGame e SemVantagem alteram o game
+// (sem vantagem, o ponto decisivo em 40 x 40 vence o game) e TiebreakEmpate define se o
+// empate em games é decidido por tiebreak ou por dois games de diferença. Nos demais
+// esportes, PontosPorGame altera os pontos de cada set, preservando a folga do limite
+// (no badminton, 21 pontos com limite 30); GamesPorSet, SemVantagem e TiebreakEmpate
+// são ignorados.
+func (r Regra) Aplicar(f Formato) Regra {
+	if f.MelhorDe != nil {
+		r.MelhorDe = *f.MelhorDe
+	}
+	if f.SuperTiebreakDecisivo != nil {
+		r.SetDecisivo = nil
+		if *f.SuperTiebreakDecisivo {
+			r.SetDecisivo = &SuperTiebreak
+		}
+	}
+
+	if !r.ContaGames() {
+		if f.PontosPorGame != nil {
+			if r.Set.Limite > 0 {
+				r.Set.Limite += *f.PontosPorGame - r.Set.Alvo
+			}
+			r.Set.Alvo = *f.PontosPorGame
+		}
+		return r
+	}
+
+	game := *r.Game
+	if f.PontosPorGame != nil {
+		game.Alvo = *f.PontosPorGame
+	}
+	if f.SemVantagem != nil {
+		game.Diferenca = 2
+		if *f.SemVantagem {
+			game.Diferenca = 1
+		}
+	}
+	r.Game = &game
+
+	if f.GamesPorSet != nil {
+		r.Set.Alvo = *f.GamesPorSet
+	}
+	if f.TiebreakEmpate != nil {
+		r.Tiebreak = nil
+		if *f.TiebreakEmpate {
+			r.Tiebreak = &TiebreakPadrao
+		}
+	}
+	r.Set.Limite = 0
+	if r.Tiebreak != nil {
+		r.Set.Limite = r.Set.Alvo + 1
+	}
+	return r
+}
+
+// SetsParaVencer retorna quantos sets um lado precisa vencer para ganhar a partida.
+func (r Regra) SetsParaVencer() int {
+	return r.MelhorDe/2 + 1
+}
+
+// RegraDoSet retorna a regra aplicada ao set de número informado (a partir de 1). Partidas
+// de set único disputam um set normal, mesmo quando a regra prevê um set decisivo.
+func (r Regra) RegraDoSet(numero int) RegraSet {
+	if r.SetDecisivo != nil && r.MelhorDe > 1 && numero == r.MelhorDe {
+		return *r.SetDecisivo
+	}
+	return r.Set
+}
+
+// Validar verifica se o placar do set é possível pela regra e retorna o lado vencedor (1 ou 2).
+func (rs RegraSet) Validar(s Set) (int, error) {
+	lado, vencedor, perdedor := 1, s.Lado1, s.Lado2
+	if s.Lado2 > s.Lado1 {
+		lado, vencedor, perdedor = 2, s.Lado2, s.Lado1
+	}
+
+	diferenca := rs.Diferenca
+	if diferenca < 1 {
+		diferenca = 1
+	}
+
+	valido := false
+	switch {
+	case perdedor < 0 || vencedor == perdedor || vencedor < rs.Alvo:
+	case rs.Limite > 0 && vencedor > rs.Limite:
+	case vencedor == rs.Alvo:
+		valido = vencedor-perdedor >= diferenca
+	case diferenca > 1:
+		// Acima do alvo o set só termina ao abrir a diferença mínima ou ao atingir o limite.
+		valido = vencedor-perdedor == diferenca || (vencedor == rs.Limite && perdedor == rs.Limite-1)
+	}
+	if !valido {
+		return 0, fmt.Errorf("%w: %d x %d (o set é vencido com %s)", ErrSetInvalido, s.Lado1, s.Lado2, rs.descricao())
+	}
+	return lado, nil
+}
+
+// descricao resume a regra do set para as mensagens de erro.
+func (rs RegraSet) descricao() string {
+	d := fmt.Sprintf("%d %s", rs.Alvo, rs.Unidade)
+	if rs.Diferenca > 1 {
+		d += fmt.Sprintf(" e %d de diferença", rs.Diferenca)
+	}
+	switch {
+	case rs.Limite == rs.Alvo+1:
+		d += fmt.Sprintf(", com tiebreak em %d x %d", rs.Alvo, rs.Alvo)
+	case rs.Limite > 0:
+		d += fmt.Sprintf(", até o máximo de %d", rs.Limite)
+	}
+	return d
+}
+
+// ValidarPartida verifica cada set e o formato da partida e retorna o lado vencedor.
+// A partida termina assim que um lado vence SetsParaVencer sets; sets informados depois
+// disso, ou a falta deles, invalidam o placar.
+func (r Regra) ValidarPartida(sets []Set) (int, error) {
+	if len(sets) > r.MelhorDe {
+		return 0, fmt.Errorf("%w: melhor de %d sets", ErrQuantidadeSetsMaxima, r.MelhorDe)
+	}
+
+	var ganhos [3]int
+	para := r.SetsParaVencer()
+	for i, s := range sets {
+		if ganhos[1] == para || ganhos[2] == para {
+			return 0, fmt.Errorf("%w: set %d", ErrSetsAposDecisao, i+1)
+		}
+		lado, err := r.RegraDoSet(i + 1).Validar(s)
+		if err != nil {
+			return 0, fmt.Errorf("set %d: %w", i+1, err)
+		}
+		ganhos[lado]++
+	}
+
+	switch para {
+	case ganhos[1]:
+		return 1, nil
+	case ganhos[2]:
+		return 2, nil
+	}
+	return 0, fmt.Errorf("%w: são necessários %d sets vencidos", ErrSetsInsuficientes, para)
+}
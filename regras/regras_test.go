@@ -0,0 +1,65 @@
+package regras
+
+import (
+	"errors"
+	"testing"
+)
+
+func beachTenisMelhorDe(t *testing.T, melhorDe int) Regra {
+	t.Helper()
+	r, ok := DoEsporte(BeachTenis)
+	if !ok {
+		t.Fatalf("regra de %s não encontrada", BeachTenis)
+	}
+	return r.Aplicar(Formato{MelhorDe: &melhorDe})
+}
+
+func TestValidarPartidaBeachTenisSetUnico(t *testing.T) {
+	r := beachTenisMelhorDe(t, 1)
+
+	if got := r.RegraDoSet(1); got != setComTiebreak {
+		t.Errorf("RegraDoSet(1) = %+v, esperado o set normal %+v", got, setComTiebreak)
+	}
+
+	casos := []struct {
+		nome     string
+		sets     []Set
+		vencedor int
+		err      error
+	}{
+		{"set normal", []Set{{6, 3}}, 1, nil},
+		{"set com tiebreak", []Set{{6, 7}}, 2, nil},
+		{"placar de super tiebreak", []Set{{10, 8}}, 0, ErrSetInvalido},
+		{"dois sets", []Set{{6, 3}, {6, 4}}, 0, ErrQuantidadeSetsMaxima},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			vencedor, err := r.ValidarPartida(c.sets)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("ValidarPartida(%v) erro = %v, esperado %v", c.sets, err, c.err)
+			}
+			if vencedor != c.vencedor {
+				t.Errorf("ValidarPartida(%v) = %d, esperado %d", c.sets, vencedor, c.vencedor)
+			}
+		})
+	}
+}
+
+func TestValidarPartidaBeachTenisSetDecisivo(t *testing.T) {
+	r := beachTenisMelhorDe(t, 3)
+
+	if vencedor, err := r.ValidarPartida([]Set{{6, 4}, {3, 6}, {10, 8}}); err != nil || vencedor != 1 {
+		t.Errorf("super tiebreak no terceiro set: vencedor = %d, erro = %v; esperado 1, nil", vencedor, err)
+	}
+	if _, err := r.ValidarPartida([]Set{{6, 4}, {3, 6}, {6, 2}}); !errors.Is(err, ErrSetInvalido) {
+		t.Errorf("set normal no lugar do super tiebreak: erro = %v, esperado %v", err, ErrSetInvalido)
+	}
+}
+
+func TestPlacarBeachTenisSetUnicoNaoUsaSuperTiebreak(t *testing.T) {
+	r := beachTenisMelhorDe(t, 1)
+	e := r.NovoEstado()
+	if r.setDecisivoAtual(&e) {
+		t.Error("o único set de uma partida melhor de 1 foi tratado como super tiebreak")
+	}
+}
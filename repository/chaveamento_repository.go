@@ -0,0 +1,464 @@
+package repository
+
+import (
+	"competitions/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Erros customizados para o repositório de chaveamentos.
+var (
+	ErrChaveamentoAvancado        = errors.New("o vencedor já avançou para um jogo iniciado ou encerrado da rodada seguinte")
+	ErrModalidadesMistas          = errors.New("a categoria possui inscrições de simples e de duplas; não é possível montar uma única chave")
+	ErrConfrontoVazio             = errors.New("um confronto da primeira rodada não pode ter os dois lados vazios")
+	ErrFaseDeGruposPendente       = errors.New("a fase de grupos desta categoria ainda possui jogos não encerrados ou não gerados")
+	ErrClassificadosInsuficientes = errors.New("um dos grupos não possui participantes suficientes para a quantidade de classificados")
+)
+
+// ChaveamentoRepository define a interface para as operações de dados de chaveamentos (eliminatória simples).
+type ChaveamentoRepository interface {
+	Create(ctx context.Context, torneioID int, input models.CriarChaveamentoInput) (models.Chaveamento, error)
+	FindByTorneioCategoria(ctx context.Context, torneioID, categoriaID int) (models.Chaveamento, error)
+	CreatePlayoffs(ctx context.Context, torneioID int, input models.CriarPlayoffsInput) (models.Chaveamento, error)
+}
+
+// pgChaveamentoRepository é a implementação concreta para ChaveamentoRepository.
+type pgChaveamentoRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewChaveamentoRepository cria uma nova instância de ChaveamentoRepository.
+func NewChaveamentoRepository(db *pgxpool.Pool) ChaveamentoRepository {
+	return &pgChaveamentoRepository{db: db}
+}
+
+// Create sorteia a chave de uma categoria do torneio. As inscrições são ordenadas pelo
+// rating no esporte do torneio e na modalidade da inscrição (em duplas, pela média do
+// rating dos dois jogadores) e posicionadas nas
+// posições padrão de cabeças de chave; os jogos da primeira rodada são criados em seguida.
+func (r *pgChaveamentoRepository) Create(ctx context.Context, torneioID int, input models.CriarChaveamentoInput) (models.Chaveamento, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.Chaveamento{}, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT jt.id, jt.tipo_modalidade
+		FROM jogadores_torneios jt
+		JOIN torneios t ON t.id = jt.id_torneio
+		LEFT JOIN duplas d ON d.id = jt.id_dupla
+		LEFT JOIN ratings_jogadores r ON r.id_jogador = jt.id_jogador
+			AND r.id_esporte = t.id_esporte AND r.tipo_modalidade = jt.tipo_modalidade
+		LEFT JOIN ratings_jogadores ra ON ra.id_jogador = d.id_jogador_a
+			AND ra.id_esporte = t.id_esporte AND ra.tipo_modalidade = jt.tipo_modalidade
+		LEFT JOIN ratings_jogadores rb ON rb.id_jogador = d.id_jogador_b
+			AND rb.id_esporte = t.id_esporte AND rb.tipo_modalidade = jt.tipo_modalidade
+		WHERE jt.id_torneio = $1 AND jt.id_categoria = $2
+		ORDER BY CASE
+			WHEN jt.tipo_modalidade = 'duplas' THEN (COALESCE(ra.rating, 1500) + COALESCE(rb.rating, 1500)) / 2.0
+			ELSE COALESCE(r.rating, 1500)
+		END DESC, jt.id`
+	rows, err := tx.Query(ctx, query, torneioID, input.CategoriaID)
+	if err != nil {
+		return models.Chaveamento{}, fmt.Errorf("falha ao buscar inscrições: %w", err)
+	}
+	var inscricoes []int
+	modalidade := ""
+	for rows.Next() {
+		var id int
+		var m string
+		if err := rows.Scan(&id, &m); err != nil {
+			rows.Close()
+			return models.Chaveamento{}, fmt.Errorf("falha ao ler inscrição: %w", err)
+		}
+		if modalidade != "" && m != modalidade {
+			rows.Close()
+			return models.Chaveamento{}, ErrModalidadesMistas
+		}
+		modalidade = m
+		inscricoes = append(inscricoes, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return models.Chaveamento{}, err
+	}
+
+	slots, err := models.DistribuirCabecasDeChave(inscricoes)
+	if err != nil {
+		return models.Chaveamento{}, err
+	}
+
+	chave, err := criarChaveamento(ctx, tx, torneioID, input.CategoriaID, modalidade, slots)
+	if err != nil {
+		return models.Chaveamento{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return models.Chaveamento{}, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return chave, nil
+}
+
+// FindByTorneioCategoria recupera a chave de uma categoria do torneio com todas as suas partidas.
+func (r *pgChaveamentoRepository) FindByTorneioCategoria(ctx context.Context, torneioID, categoriaID int) (models.Chaveamento, error) {
+	var id int
+	err := r.db.QueryRow(ctx, "SELECT id FROM chaveamentos WHERE id_torneio = $1 AND id_categoria = $2", torneioID, categoriaID).Scan(&id)
+	if err != nil {
+		return models.Chaveamento{}, err
+	}
+	return buscarChaveamento(ctx, r.db, id)
+}
+
+// CreatePlayoffs gera a fase eliminatória de uma categoria a partir da classificação final
+// de cada um de seus grupos. Todos os jogos da fase de grupos precisam estar encerrados.
+func (r *pgChaveamentoRepository) CreatePlayoffs(ctx context.Context, torneioID int, input models.CriarPlayoffsInput) (models.Chaveamento, error) {
+	porGrupo := input.ClassificadosPorGrupo
+	if porGrupo == 0 {
+		porGrupo = 2
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.Chaveamento{}, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, "SELECT id FROM grupos WHERE id_torneio = $1 AND id_categoria = $2 ORDER BY id", torneioID, input.CategoriaID)
+	if err != nil {
+		return models.Chaveamento{}, fmt.Errorf("falha ao buscar grupos: %w", err)
+	}
+	grupoIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return models.Chaveamento{}, fmt.Errorf("falha ao ler grupos: %w", err)
+	}
+	if len(grupoIDs) == 0 {
+		return models.Chaveamento{}, ErrGruposNaoEncontrados
+	}
+
+	var total, pendentes int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE situacao <> 'encerrado')
+		FROM jogos WHERE id_grupo = ANY($1)`, grupoIDs,
+	).Scan(&total, &pendentes)
+	if err != nil {
+		return models.Chaveamento{}, fmt.Errorf("falha ao verificar jogos da fase de grupos: %w", err)
+	}
+	if total == 0 || pendentes > 0 {
+		return models.Chaveamento{}, ErrFaseDeGruposPendente
+	}
+
+	classificados := make([][]int, 0, len(grupoIDs))
+	for _, grupoID := range grupoIDs {
+		classificacao, err := calcularClassificacaoGrupo(ctx, tx, grupoID)
+		if err != nil {
+			return models.Chaveamento{}, err
+		}
+		if len(classificacao) < porGrupo {
+			return models.Chaveamento{}, ErrClassificadosInsuficientes
+		}
+		ordem := make([]int, porGrupo)
+		for i := range ordem {
+			ordem[i] = classificacao[i].InscricaoID
+		}
+		classificados = append(classificados, ordem)
+	}
+
+	var modalidade string
+	if err := tx.QueryRow(ctx, "SELECT tipo_modalidade FROM jogadores_torneios WHERE id = $1", classificados[0][0]).Scan(&modalidade); err != nil {
+		return models.Chaveamento{}, fmt.Errorf("falha ao buscar modalidade da categoria: %w", err)
+	}
+
+	slots, err := models.CruzarClassificadosGrupos(classificados)
+	if err != nil {
+		return models.Chaveamento{}, err
+	}
+	chave, err := criarChaveamento(ctx, tx, torneioID, input.CategoriaID, modalidade, slots)
+	if err != nil {
+		return models.Chaveamento{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return models.Chaveamento{}, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return chave, nil
+}
+
+// colunasPartida lista as colunas lidas por scanPartida, na mesma ordem.
+// As consultas devem usar o alias 'cp' para chaveamento_partidas e 'ro' para rodadas.
+const colunasPartida = `
+	cp.id, cp.id_chaveamento, cp.rodada, cp.posicao, cp.id_rodada, ro.nome, cp.id_inscricao1, cp.id_inscricao2,
+	cp.seed1, cp.seed2, cp.id_inscricao_vencedora, cp.id_jogo
+	FROM chaveamento_partidas cp
+	JOIN rodadas ro ON ro.id = cp.id_rodada`
+
+// scanPartida lê uma linha com as colunas de colunasPartida para um models.PartidaChaveamento.
+func scanPartida(row pgx.Row) (models.PartidaChaveamento, error) {
+	var p models.PartidaChaveamento
+	err := row.Scan(
+		&p.ID, &p.ChaveamentoID, &p.Rodada, &p.Posicao, &p.RodadaID, &p.NomeRodada, &p.Inscricao1ID, &p.Inscricao2ID,
+		&p.Seed1, &p.Seed2, &p.InscricaoVencedorID, &p.JogoID,
+	)
+	return p, err
+}
+
+// buscarChaveamento lê uma chave e suas partidas, ordenadas por rodada e posição.
+func buscarChaveamento(ctx context.Context, db consultor, id int) (models.Chaveamento, error) {
+	var c models.Chaveamento
+	err := db.QueryRow(ctx, "SELECT id, id_torneio, id_categoria, tipo_modalidade, tamanho FROM chaveamentos WHERE id = $1", id).
+		Scan(&c.ID, &c.TorneioID, &c.CategoriaID, &c.TipoModalidade, &c.Tamanho)
+	if err != nil {
+		return c, err
+	}
+
+	rows, err := db.Query(ctx, "SELECT"+colunasPartida+" WHERE cp.id_chaveamento = $1 ORDER BY cp.rodada, cp.posicao", id)
+	if err != nil {
+		return c, err
+	}
+	defer rows.Close()
+
+	c.Partidas = []models.PartidaChaveamento{}
+	for rows.Next() {
+		p, err := scanPartida(rows)
+		if err != nil {
+			return c, fmt.Errorf("falha ao ler partida do chaveamento: %w", err)
+		}
+		c.Partidas = append(c.Partidas, p)
+	}
+	return c, rows.Err()
+}
+
+// criarChaveamento persiste uma chave a partir dos slots da primeira rodada, criando uma
+// rodada por fase, todas as partidas da chave, os jogos dos confrontos já definidos e
+// avançando automaticamente os participantes que receberam "bye".
+func criarChaveamento(ctx context.Context, tx pgx.Tx, torneioID, categoriaID int, modalidade string, slots []models.SlotChave) (models.Chaveamento, error) {
+	tamanho := len(slots)
+	for i := 0; i < tamanho; i += 2 {
+		if slots[i].InscricaoID == 0 && slots[i+1].InscricaoID == 0 {
+			return models.Chaveamento{}, ErrConfrontoVazio
+		}
+	}
+
+	var chaveID int
+	err := tx.QueryRow(ctx, `
+		INSERT INTO chaveamentos (id_torneio, id_categoria, tipo_modalidade, tamanho)
+		VALUES ($1, $2, $3, $4) RETURNING id`,
+		torneioID, categoriaID, modalidade, tamanho,
+	).Scan(&chaveID)
+	if err != nil {
+		return models.Chaveamento{}, err
+	}
+
+	// Cria as rodadas e as partidas; apenas a primeira rodada recebe participantes.
+	var primeiraRodada []int
+	for rodada, participantes := 1, tamanho; participantes >= 2; rodada, participantes = rodada+1, participantes/2 {
+		var rodadaID int
+		nome := models.NomeRodadaChave(participantes)
+		err := tx.QueryRow(ctx, "INSERT INTO rodadas (id_torneio, numero, nome) VALUES ($1, $2, $3) RETURNING id",
+			torneioID, rodada, nome,
+		).Scan(&rodadaID)
+		if err != nil {
+			return models.Chaveamento{}, fmt.Errorf("falha ao criar rodada '%s': %w", nome, err)
+		}
+
+		for posicao := 1; posicao <= participantes/2; posicao++ {
+			var insc1, insc2, seed1, seed2 *int
+			if rodada == 1 {
+				insc1, seed1 = slotParaColunas(slots[2*posicao-2])
+				insc2, seed2 = slotParaColunas(slots[2*posicao-1])
+			}
+			var partidaID int
+			err := tx.QueryRow(ctx, `
+				INSERT INTO chaveamento_partidas (id_chaveamento, rodada, posicao, id_rodada, id_inscricao1, id_inscricao2, seed1, seed2)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+				chaveID, rodada, posicao, rodadaID, insc1, insc2, seed1, seed2,
+			).Scan(&partidaID)
+			if err != nil {
+				return models.Chaveamento{}, fmt.Errorf("falha ao criar partida %d da rodada %d: %w", posicao, rodada, err)
+			}
+			if rodada == 1 {
+				primeiraRodada = append(primeiraRodada, partidaID)
+			}
+		}
+	}
+
+	// Cria os jogos da primeira rodada e avança quem recebeu "bye".
+	for _, partidaID := range primeiraRodada {
+		p, err := scanPartida(tx.QueryRow(ctx, "SELECT"+colunasPartida+" WHERE cp.id = $1 FOR UPDATE OF cp", partidaID))
+		if err != nil {
+			return models.Chaveamento{}, err
+		}
+		switch {
+		case p.Inscricao1ID != nil && p.Inscricao2ID != nil:
+			if err := criarJogoPartida(ctx, tx, p); err != nil {
+				return models.Chaveamento{}, err
+			}
+		case p.Inscricao1ID != nil:
+			if err := definirVencedorPartida(ctx, tx, p, *p.Inscricao1ID); err != nil {
+				return models.Chaveamento{}, err
+			}
+		default:
+			if err := definirVencedorPartida(ctx, tx, p, *p.Inscricao2ID); err != nil {
+				return models.Chaveamento{}, err
+			}
+		}
+	}
+
+	return buscarChaveamento(ctx, tx, chaveID)
+}
+
+// slotParaColunas converte um slot em valores anuláveis para as colunas da partida.
+func slotParaColunas(s models.SlotChave) (inscricao, seed *int) {
+	if s.InscricaoID == 0 {
+		return nil, nil
+	}
+	inscricao = &s.InscricaoID
+	if s.Seed > 0 {
+		seed = &s.Seed
+	}
+	return inscricao, seed
+}
+
+// ladoInscricao retorna a modalidade e a dupla de uma inscrição, usadas para preencher um lado do jogo.
+func ladoInscricao(ctx context.Context, tx pgx.Tx, inscricaoID int) (modalidade string, duplaID *int, err error) {
+	err = tx.QueryRow(ctx, "SELECT tipo_modalidade, id_dupla FROM jogadores_torneios WHERE id = $1", inscricaoID).Scan(&modalidade, &duplaID)
+	if err != nil {
+		return "", nil, fmt.Errorf("falha ao buscar inscrição %d: %w", inscricaoID, err)
+	}
+	return modalidade, duplaID, nil
+}
+
+// criarJogoPartida cria o jogo de uma partida cujos dois lados já estão definidos.
+// O jogo da última rodada é marcado como final do campeonato.
+func criarJogoPartida(ctx context.Context, tx pgx.Tx, p models.PartidaChaveamento) error {
+	var torneioID int
+	var final bool
+	err := tx.QueryRow(ctx, `
+		SELECT c.id_torneio, NOT EXISTS (
+			SELECT 1 FROM chaveamento_partidas cp WHERE cp.id_chaveamento = c.id AND cp.rodada > $2
+		)
+		FROM chaveamentos c WHERE c.id = $1`, p.ChaveamentoID, p.Rodada,
+	).Scan(&torneioID, &final)
+	if err != nil {
+		return fmt.Errorf("falha ao buscar chaveamento %d: %w", p.ChaveamentoID, err)
+	}
+
+	modalidade, dupla1, err := ladoInscricao(ctx, tx, *p.Inscricao1ID)
+	if err != nil {
+		return err
+	}
+	_, dupla2, err := ladoInscricao(ctx, tx, *p.Inscricao2ID)
+	if err != nil {
+		return err
+	}
+
+	input := models.JogoInput{
+		TorneioID:         torneioID,
+		RodadaID:          p.RodadaID,
+		TipoModalidade:    modalidade,
+		EhFinalCampeonato: final,
+	}
+	if modalidade == "duplas" {
+		input.Dupla1ID, input.Dupla2ID = dupla1, dupla2
+	} else {
+		input.JogadorTorneio1ID, input.JogadorTorneio2ID = p.Inscricao1ID, p.Inscricao2ID
+	}
+	jogo, err := inserirJogo(ctx, tx, input)
+	if err != nil {
+		return fmt.Errorf("falha ao criar jogo da partida %d: %w", p.ID, err)
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE chaveamento_partidas SET id_jogo = $1 WHERE id = $2", jogo.ID, p.ID); err != nil {
+		return fmt.Errorf("falha ao associar jogo à partida %d: %w", p.ID, err)
+	}
+	return nil
+}
+
+// definirVencedorPartida registra o vencedor de uma partida e o coloca no lado
+// correspondente da partida seguinte. Quando os dois lados da partida seguinte ficam
+// definidos, o jogo dela é criado. Se o vencedor for corrigido depois de avançar, o
+// participante é substituído no jogo seguinte, desde que ele ainda não tenha começado.
+func definirVencedorPartida(ctx context.Context, tx pgx.Tx, p models.PartidaChaveamento, vencedor int) error {
+	if _, err := tx.Exec(ctx, "UPDATE chaveamento_partidas SET id_inscricao_vencedora = $1 WHERE id = $2", vencedor, p.ID); err != nil {
+		return fmt.Errorf("falha ao registrar vencedor da partida %d: %w", p.ID, err)
+	}
+
+	proxima, err := scanPartida(tx.QueryRow(ctx,
+		"SELECT"+colunasPartida+" WHERE cp.id_chaveamento = $1 AND cp.rodada = $2 AND cp.posicao = $3 FOR UPDATE OF cp",
+		p.ChaveamentoID, p.Rodada+1, (p.Posicao+1)/2,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil // A partida era a final.
+	}
+	if err != nil {
+		return fmt.Errorf("falha ao buscar partida seguinte à partida %d: %w", p.ID, err)
+	}
+
+	lado := 2 - p.Posicao%2
+	seed := p.Seed1
+	if p.Inscricao2ID != nil && *p.Inscricao2ID == vencedor {
+		seed = p.Seed2
+	}
+
+	if proxima.JogoID != nil {
+		var situacao string
+		if err := tx.QueryRow(ctx, "SELECT situacao FROM jogos WHERE id = $1 FOR UPDATE", *proxima.JogoID).Scan(&situacao); err != nil {
+			return fmt.Errorf("falha ao buscar jogo %d: %w", *proxima.JogoID, err)
+		}
+		if situacao != "aguardando" {
+			return ErrChaveamentoAvancado
+		}
+		modalidade, dupla, err := ladoInscricao(ctx, tx, vencedor)
+		if err != nil {
+			return err
+		}
+		coluna, valor := fmt.Sprintf("id_jogador_torneio%d", lado), &vencedor
+		if modalidade == "duplas" {
+			coluna, valor = fmt.Sprintf("id_dupla%d", lado), dupla
+		}
+		if _, err := tx.Exec(ctx, "UPDATE jogos SET "+coluna+" = $1 WHERE id = $2", valor, *proxima.JogoID); err != nil {
+			return fmt.Errorf("falha ao atualizar participante do jogo %d: %w", *proxima.JogoID, err)
+		}
+	}
+
+	query := fmt.Sprintf("UPDATE chaveamento_partidas SET id_inscricao%d = $1, seed%d = $2 WHERE id = $3", lado, lado)
+	if _, err := tx.Exec(ctx, query, vencedor, seed, proxima.ID); err != nil {
+		return fmt.Errorf("falha ao avançar vencedor para a partida %d: %w", proxima.ID, err)
+	}
+	if lado == 1 {
+		proxima.Inscricao1ID, proxima.Seed1 = &vencedor, seed
+	} else {
+		proxima.Inscricao2ID, proxima.Seed2 = &vencedor, seed
+	}
+
+	if proxima.JogoID == nil && proxima.Inscricao1ID != nil && proxima.Inscricao2ID != nil {
+		return criarJogoPartida(ctx, tx, proxima)
+	}
+	return nil
+}
+
+// avancarVencedorChaveamento avança o vencedor de um jogo de chaveamento para a partida
+// seguinte. Jogos que não pertencem a um chaveamento são ignorados.
+func avancarVencedorChaveamento(ctx context.Context, tx pgx.Tx, jogoID, ladoVencedor int) error {
+	p, err := scanPartida(tx.QueryRow(ctx, "SELECT"+colunasPartida+" WHERE cp.id_jogo = $1 FOR UPDATE OF cp", jogoID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("falha ao buscar partida do jogo %d: %w", jogoID, err)
+	}
+
+	vencedor := p.Inscricao1ID
+	if ladoVencedor == 2 {
+		vencedor = p.Inscricao2ID
+	}
+	if vencedor == nil {
+		return fmt.Errorf("partida %d do chaveamento não possui os dois lados definidos", p.ID)
+	}
+	if p.InscricaoVencedorID != nil && *p.InscricaoVencedorID == *vencedor {
+		return nil
+	}
+	return definirVencedorPartida(ctx, tx, p, *vencedor)
+}
@@ -0,0 +1,346 @@
+package repository
+
+import (
+	"competitions/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrEsporteCircuitoDiferente é retornado ao incluir em um circuito um torneio de outro esporte.
+var ErrEsporteCircuitoDiferente = errors.New("o torneio não pertence ao esporte do circuito")
+
+// CircuitoRepository define a interface para as operações de dados de circuitos.
+type CircuitoRepository interface {
+	Create(ctx context.Context, input models.CircuitoInput, criadorID int) (models.Circuito, error)
+	FindAll(ctx context.Context, esporteID, temporada int) ([]models.Circuito, error)
+	FindByID(ctx context.Context, id int) (models.Circuito, error)
+	Update(ctx context.Context, id int, input models.CircuitoInput) (int64, error)
+	Delete(ctx context.Context, id int) (int64, error)
+	AdicionarTorneio(ctx context.Context, id int, input models.TorneioCircuitoInput) error
+	RemoverTorneio(ctx context.Context, id, torneioID int) (int64, error)
+	FindClassificacao(ctx context.Context, id, categoriaID int, modalidade string) (models.ClassificacaoCircuito, error)
+	EhCriador(ctx context.Context, circuitoID, usuarioID int) (bool, error)
+	EhOrganizadorTorneio(ctx context.Context, torneioID, usuarioID int) (bool, error)
+}
+
+// pgCircuitoRepository é a implementação concreta para CircuitoRepository.
+type pgCircuitoRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCircuitoRepository cria uma nova instância de CircuitoRepository.
+func NewCircuitoRepository(db *pgxpool.Pool) CircuitoRepository {
+	return &pgCircuitoRepository{db: db}
+}
+
+// consultaCircuitos seleciona as colunas lidas por scanCircuito.
+const consultaCircuitos = `
+	SELECT id, id_esporte, nome, temporada, melhores_resultados, pontos_participacao, id_usuario_criador, criado_em
+	FROM circuitos`
+
+// scanCircuito lê uma linha de consultaCircuitos para um models.Circuito.
+func scanCircuito(row pgx.Row) (models.Circuito, error) {
+	var c models.Circuito
+	err := row.Scan(&c.ID, &c.EsporteID, &c.Nome, &c.Temporada, &c.MelhoresResultados, &c.PontosParticipacao, &c.CriadorID, &c.CriadoEm)
+	return c, err
+}
+
+// Create insere um novo circuito com a sua tabela de pontos, registrando o usuário que o
+// criou como dono. Sem tabela informada, é usada models.PontuacaoCircuitoPadrao.
+func (r *pgCircuitoRepository) Create(ctx context.Context, input models.CircuitoInput, criadorID int) (models.Circuito, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.Circuito{}, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var id int
+	err = tx.QueryRow(ctx, `
+		INSERT INTO circuitos (id_esporte, nome, temporada, melhores_resultados, pontos_participacao, id_usuario_criador)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		input.EsporteID, input.Nome, input.Temporada, input.MelhoresResultados, input.PontosParticipacao, criadorID,
+	).Scan(&id)
+	if err != nil {
+		return models.Circuito{}, err
+	}
+
+	pontuacao := input.Pontuacao
+	if len(pontuacao) == 0 {
+		pontuacao = models.PontuacaoCircuitoPadrao
+	}
+	if err := gravarPontuacaoCircuito(ctx, tx, id, pontuacao); err != nil {
+		return models.Circuito{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.Circuito{}, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return r.FindByID(ctx, id)
+}
+
+// FindAll recupera os circuitos, opcionalmente filtrados por esporte e temporada (zero ignora o filtro).
+func (r *pgCircuitoRepository) FindAll(ctx context.Context, esporteID, temporada int) ([]models.Circuito, error) {
+	query := consultaCircuitos + `
+		WHERE ($1 = 0 OR id_esporte = $1) AND ($2 = 0 OR temporada = $2)
+		ORDER BY temporada DESC, nome`
+	rows, err := r.db.Query(ctx, query, esporteID, temporada)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	circuitos := []models.Circuito{}
+	for rows.Next() {
+		c, err := scanCircuito(rows)
+		if err != nil {
+			return nil, err
+		}
+		circuitos = append(circuitos, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range circuitos {
+		if circuitos[i].Pontuacao, err = buscarPontuacaoCircuito(ctx, r.db, circuitos[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return circuitos, nil
+}
+
+// FindByID recupera um circuito com a sua tabela de pontos e os seus torneios.
+func (r *pgCircuitoRepository) FindByID(ctx context.Context, id int) (models.Circuito, error) {
+	c, err := scanCircuito(r.db.QueryRow(ctx, consultaCircuitos+" WHERE id = $1", id))
+	if err != nil {
+		return c, err
+	}
+	if c.Pontuacao, err = buscarPontuacaoCircuito(ctx, r.db, id); err != nil {
+		return c, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT t.id, t.nome, t.inicio, t.fim
+		FROM circuitos_torneios ct
+		JOIN torneios t ON t.id = ct.id_torneio
+		WHERE ct.id_circuito = $1
+		ORDER BY t.inicio, t.id`, id)
+	if err != nil {
+		return c, err
+	}
+	defer rows.Close()
+
+	c.Torneios = []models.TorneioCircuito{}
+	for rows.Next() {
+		var t models.TorneioCircuito
+		if err := rows.Scan(&t.ID, &t.Nome, &t.DataInicio, &t.DataFim); err != nil {
+			return c, err
+		}
+		c.Torneios = append(c.Torneios, t)
+	}
+	return c, rows.Err()
+}
+
+// Update altera os dados de um circuito e substitui a sua tabela de pontos. Sem tabela
+// informada, a tabela atual é mantida.
+func (r *pgCircuitoRepository) Update(ctx context.Context, id int, input models.CircuitoInput) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE circuitos SET id_esporte = $1, nome = $2, temporada = $3, melhores_resultados = $4, pontos_participacao = $5
+		WHERE id = $6`,
+		input.EsporteID, input.Nome, input.Temporada, input.MelhoresResultados, input.PontosParticipacao, id,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if result.RowsAffected() == 0 {
+		return 0, nil
+	}
+
+	if len(input.Pontuacao) > 0 {
+		if _, err := tx.Exec(ctx, "DELETE FROM circuitos_pontuacoes WHERE id_circuito = $1", id); err != nil {
+			return 0, fmt.Errorf("falha ao remover pontuação do circuito: %w", err)
+		}
+		if err := gravarPontuacaoCircuito(ctx, tx, id, input.Pontuacao); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// Delete remove um circuito. Os torneios não são afetados.
+func (r *pgCircuitoRepository) Delete(ctx context.Context, id int) (int64, error) {
+	result, err := r.db.Exec(ctx, "DELETE FROM circuitos WHERE id = $1", id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// AdicionarTorneio inclui um torneio no circuito. O torneio precisa ser do esporte do circuito.
+func (r *pgCircuitoRepository) AdicionarTorneio(ctx context.Context, id int, input models.TorneioCircuitoInput) error {
+	var mesmoEsporte bool
+	err := r.db.QueryRow(ctx, `
+		SELECT c.id_esporte = t.id_esporte
+		FROM circuitos c, torneios t
+		WHERE c.id = $1 AND t.id = $2`, id, input.TorneioID,
+	).Scan(&mesmoEsporte)
+	if err != nil {
+		return err
+	}
+	if !mesmoEsporte {
+		return ErrEsporteCircuitoDiferente
+	}
+
+	_, err = r.db.Exec(ctx, "INSERT INTO circuitos_torneios (id_circuito, id_torneio) VALUES ($1, $2)", id, input.TorneioID)
+	return err
+}
+
+// RemoverTorneio retira um torneio do circuito.
+func (r *pgCircuitoRepository) RemoverTorneio(ctx context.Context, id, torneioID int) (int64, error) {
+	result, err := r.db.Exec(ctx, "DELETE FROM circuitos_torneios WHERE id_circuito = $1 AND id_torneio = $2", id, torneioID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// FindClassificacao calcula a classificação de uma categoria e modalidade do circuito a partir
+// das colocações finais nas chaves dos seus torneios. Apenas chaves com a final decidida são
+// consideradas. Quem perdeu um jogo da chave recebe a colocação da rodada em que foi eliminado,
+// o vencedor da final é o campeão e as inscrições da categoria que não entraram na chave
+// recebem a colocação de participação. Vitórias por "bye" não contam como eliminação.
+func (r *pgCircuitoRepository) FindClassificacao(ctx context.Context, id, categoriaID int, modalidade string) (models.ClassificacaoCircuito, error) {
+	classificacao := models.ClassificacaoCircuito{CircuitoID: id, CategoriaID: categoriaID, TipoModalidade: modalidade}
+
+	circuito, err := scanCircuito(r.db.QueryRow(ctx, consultaCircuitos+" WHERE id = $1", id))
+	if err != nil {
+		return classificacao, err
+	}
+	pontuacao, err := buscarPontuacaoCircuito(ctx, r.db, id)
+	if err != nil {
+		return classificacao, err
+	}
+
+	query := `
+		WITH chaves AS (
+			SELECT c.id, c.id_torneio, c.id_categoria, c.tamanho
+			FROM chaveamentos c
+			JOIN circuitos_torneios ct ON ct.id_torneio = c.id_torneio
+			WHERE ct.id_circuito = $1 AND c.id_categoria = $2 AND c.tipo_modalidade = $3
+			AND EXISTS (
+				SELECT 1 FROM chaveamento_partidas f
+				WHERE f.id_chaveamento = c.id AND f.rodada = (
+					SELECT MAX(m.rodada) FROM chaveamento_partidas m WHERE m.id_chaveamento = c.id
+				) AND f.id_inscricao_vencedora IS NOT NULL
+			)
+		),
+		colocacoes AS (
+			SELECT ch.id_torneio, cp.id_inscricao_vencedora AS id_inscricao, 1 AS colocacao
+			FROM chaves ch
+			JOIN chaveamento_partidas cp ON cp.id_chaveamento = ch.id
+			WHERE cp.rodada = (SELECT MAX(m.rodada) FROM chaveamento_partidas m WHERE m.id_chaveamento = ch.id)
+			UNION ALL
+			SELECT ch.id_torneio,
+				CASE WHEN cp.id_inscricao_vencedora = cp.id_inscricao1 THEN cp.id_inscricao2 ELSE cp.id_inscricao1 END,
+				ch.tamanho >> (cp.rodada - 1)
+			FROM chaves ch
+			JOIN chaveamento_partidas cp ON cp.id_chaveamento = ch.id
+			WHERE cp.id_inscricao_vencedora IS NOT NULL AND cp.id_inscricao1 IS NOT NULL AND cp.id_inscricao2 IS NOT NULL
+			UNION ALL
+			SELECT ch.id_torneio, jt.id, $4
+			FROM chaves ch
+			JOIN jogadores_torneios jt ON jt.id_torneio = ch.id_torneio AND jt.id_categoria = ch.id_categoria
+			WHERE NOT EXISTS (
+				SELECT 1 FROM chaveamento_partidas cp
+				WHERE cp.id_chaveamento = ch.id AND jt.id IN (cp.id_inscricao1, cp.id_inscricao2)
+			)
+		)
+		SELECT t.id, t.nome, j.id, j.nome, co.colocacao
+		FROM colocacoes co
+		JOIN torneios t ON t.id = co.id_torneio
+		JOIN jogadores_torneios jt ON jt.id = co.id_inscricao
+		LEFT JOIN duplas d ON d.id = jt.id_dupla
+		JOIN jogadores j ON j.id IN (jt.id_jogador, d.id_jogador_a, d.id_jogador_b)
+		ORDER BY t.inicio, t.id, co.colocacao`
+	rows, err := r.db.Query(ctx, query, id, categoriaID, modalidade, models.ColocacaoParticipacao)
+	if err != nil {
+		return classificacao, err
+	}
+	defer rows.Close()
+
+	var resultados []models.ResultadoCircuito
+	for rows.Next() {
+		var res models.ResultadoCircuito
+		if err := rows.Scan(&res.TorneioID, &res.NomeTorneio, &res.JogadorID, &res.NomeJogador, &res.Colocacao); err != nil {
+			return classificacao, fmt.Errorf("falha ao ler resultado do circuito: %w", err)
+		}
+		resultados = append(resultados, res)
+	}
+	if err := rows.Err(); err != nil {
+		return classificacao, err
+	}
+
+	classificacao.Classificacao = models.ClassificarCircuito(resultados, pontuacao, circuito.PontosParticipacao, circuito.MelhoresResultados)
+	return classificacao, nil
+}
+
+// EhCriador informa se o usuário criou o circuito. Retorna pgx.ErrNoRows se o circuito não existir.
+func (r *pgCircuitoRepository) EhCriador(ctx context.Context, circuitoID, usuarioID int) (bool, error) {
+	var criador bool
+	err := r.db.QueryRow(ctx,
+		"SELECT COALESCE(id_usuario_criador = $2, FALSE) FROM circuitos WHERE id = $1", circuitoID, usuarioID,
+	).Scan(&criador)
+	return criador, err
+}
+
+// EhOrganizadorTorneio informa se o usuário criou ou é coorganizador do torneio, usado ao
+// incluir torneios no circuito. Retorna pgx.ErrNoRows se o torneio não existir.
+func (r *pgCircuitoRepository) EhOrganizadorTorneio(ctx context.Context, torneioID, usuarioID int) (bool, error) {
+	return organizaTorneio(ctx, r.db, torneioPorID, torneioID, usuarioID, false)
+}
+
+// buscarPontuacaoCircuito lê a tabela de pontos de um circuito, do campeão para as fases iniciais.
+func buscarPontuacaoCircuito(ctx context.Context, db consultor, circuitoID int) ([]models.PontuacaoCircuito, error) {
+	rows, err := db.Query(ctx, "SELECT colocacao, pontos FROM circuitos_pontuacoes WHERE id_circuito = $1 ORDER BY colocacao", circuitoID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar pontuação do circuito: %w", err)
+	}
+	defer rows.Close()
+
+	pontuacao := []models.PontuacaoCircuito{}
+	for rows.Next() {
+		var p models.PontuacaoCircuito
+		if err := rows.Scan(&p.Colocacao, &p.Pontos); err != nil {
+			return nil, err
+		}
+		p.Fase = models.NomeColocacao(p.Colocacao)
+		pontuacao = append(pontuacao, p)
+	}
+	return pontuacao, rows.Err()
+}
+
+// gravarPontuacaoCircuito insere a tabela de pontos de um circuito.
+func gravarPontuacaoCircuito(ctx context.Context, tx pgx.Tx, circuitoID int, pontuacao []models.PontuacaoCircuito) error {
+	for _, p := range pontuacao {
+		_, err := tx.Exec(ctx, "INSERT INTO circuitos_pontuacoes (id_circuito, colocacao, pontos) VALUES ($1, $2, $3)",
+			circuitoID, p.Colocacao, p.Pontos)
+		if err != nil {
+			return fmt.Errorf("falha ao gravar pontuação do circuito: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"competitions/models"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ClubeRepository define a interface para as operações de dados de clubes.
+type ClubeRepository interface {
+	Create(ctx context.Context, input models.ClubeInput, criadorID int) (models.Clube, error)
+	FindAll(ctx context.Context) ([]models.Clube, error)
+	FindByID(ctx context.Context, id int) (models.Clube, error)
+	Update(ctx context.Context, id int, input models.ClubeInput) (int64, error)
+	Delete(ctx context.Context, id int) (int64, error)
+	FindOrganizadores(ctx context.Context, clubeID int) ([]models.OrganizadorClube, error)
+	AdicionarOrganizador(ctx context.Context, clubeID, usuarioID int) error
+	RemoverOrganizador(ctx context.Context, clubeID, usuarioID int) (int64, error)
+	EhOrganizador(ctx context.Context, clubeID, usuarioID int) (bool, error)
+	EhCriador(ctx context.Context, clubeID, usuarioID int) (bool, error)
+}
+
+// pgClubeRepository é a implementação concreta para ClubeRepository.
+type pgClubeRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewClubeRepository cria uma nova instância de ClubeRepository.
+func NewClubeRepository(db *pgxpool.Pool) ClubeRepository {
+	return &pgClubeRepository{db: db}
+}
+
+// colunasClube são as colunas lidas por scanClube.
+const colunasClube = `
+	id, id_jogador_responsavel, nome, telefone, whatsapp, instagram, id_cidade, id_estado, id_pais,
+	quantidade, id_usuario_criador, ativo, criado_em`
+
+// scanClube lê uma linha com colunasClube para um models.Clube.
+func scanClube(row pgx.Row) (models.Clube, error) {
+	var c models.Clube
+	err := row.Scan(
+		&c.ID, &c.JogadorResponsavelID, &c.Nome, &c.Telefone, &c.Whatsapp, &c.Instagram, &c.CidadeID, &c.EstadoID, &c.PaisID,
+		&c.Quantidade, &c.CriadorID, &c.Ativo, &c.CriadoEm,
+	)
+	return c, err
+}
+
+// Create insere um novo clube, registrando o usuário que o criou como dono.
+func (r *pgClubeRepository) Create(ctx context.Context, input models.ClubeInput, criadorID int) (models.Clube, error) {
+	query := `
+		INSERT INTO clubes (id_jogador_responsavel, nome, telefone, whatsapp, instagram, id_cidade, id_estado, id_pais,
+			ativo, id_usuario_criador)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, COALESCE($9, TRUE), $10)
+		RETURNING` + colunasClube
+	return scanClube(r.db.QueryRow(ctx, query,
+		input.JogadorResponsavelID, input.Nome, input.Telefone, input.Whatsapp, input.Instagram,
+		input.CidadeID, input.EstadoID, input.PaisID, input.Ativo, criadorID,
+	))
+}
+
+// FindAll recupera todos os clubes, ordenados pelo nome.
+func (r *pgClubeRepository) FindAll(ctx context.Context) ([]models.Clube, error) {
+	rows, err := r.db.Query(ctx, "SELECT"+colunasClube+" FROM clubes ORDER BY nome")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clubes := []models.Clube{}
+	for rows.Next() {
+		c, err := scanClube(rows)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler clube: %w", err)
+		}
+		clubes = append(clubes, c)
+	}
+	return clubes, rows.Err()
+}
+
+// FindByID recupera um único clube pelo seu ID.
+func (r *pgClubeRepository) FindByID(ctx context.Context, id int) (models.Clube, error) {
+	return scanClube(r.db.QueryRow(ctx, "SELECT"+colunasClube+" FROM clubes WHERE id = $1", id))
+}
+
+// Update modifica um clube existente. A situação (ativo) só muda quando informada.
+func (r *pgClubeRepository) Update(ctx context.Context, id int, input models.ClubeInput) (int64, error) {
+	query := `
+		UPDATE clubes
+		SET id_jogador_responsavel = $1, nome = $2, telefone = $3, whatsapp = NULLIF($4, ''), instagram = NULLIF($5, ''),
+			id_cidade = $6, id_estado = $7, id_pais = $8, ativo = COALESCE($9, ativo)
+		WHERE id = $10`
+	result, err := r.db.Exec(ctx, query,
+		input.JogadorResponsavelID, input.Nome, input.Telefone, input.Whatsapp, input.Instagram,
+		input.CidadeID, input.EstadoID, input.PaisID, input.Ativo, id,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// Delete remove um clube, junto com os seus membros e coorganizadores.
+func (r *pgClubeRepository) Delete(ctx context.Context, id int) (int64, error) {
+	result, err := r.db.Exec(ctx, "DELETE FROM clubes WHERE id = $1", id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// FindOrganizadores lista os organizadores de um clube: o criador, seguido dos coorganizadores.
+func (r *pgClubeRepository) FindOrganizadores(ctx context.Context, clubeID int) ([]models.OrganizadorClube, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT u.id, u.nome, u.username, TRUE
+		FROM clubes c JOIN usuarios u ON u.id = c.id_usuario_criador
+		WHERE c.id = $1
+		UNION ALL
+		SELECT u.id, u.nome, u.username, FALSE
+		FROM clubes_organizadores o
+		JOIN clubes c ON c.id = o.id_clube
+		JOIN usuarios u ON u.id = o.id_usuario
+		WHERE o.id_clube = $1 AND o.id_usuario IS DISTINCT FROM c.id_usuario_criador
+		ORDER BY 4 DESC, 2`, clubeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizadores := []models.OrganizadorClube{}
+	for rows.Next() {
+		var o models.OrganizadorClube
+		if err := rows.Scan(&o.UsuarioID, &o.Nome, &o.Username, &o.Criador); err != nil {
+			return nil, fmt.Errorf("falha ao ler organizador do clube: %w", err)
+		}
+		organizadores = append(organizadores, o)
+	}
+	return organizadores, rows.Err()
+}
+
+// AdicionarOrganizador adiciona um coorganizador ao clube. Adicionar um usuário que já é
+// coorganizador não tem efeito.
+func (r *pgClubeRepository) AdicionarOrganizador(ctx context.Context, clubeID, usuarioID int) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO clubes_organizadores (id_clube, id_usuario) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, clubeID, usuarioID)
+	return err
+}
+
+// RemoverOrganizador remove um coorganizador do clube. O criador não pode ser removido.
+func (r *pgClubeRepository) RemoverOrganizador(ctx context.Context, clubeID, usuarioID int) (int64, error) {
+	result, err := r.db.Exec(ctx,
+		"DELETE FROM clubes_organizadores WHERE id_clube = $1 AND id_usuario = $2", clubeID, usuarioID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// EhOrganizador informa se o usuário criou ou é coorganizador do clube. Retorna
+// pgx.ErrNoRows se o clube não existir.
+func (r *pgClubeRepository) EhOrganizador(ctx context.Context, clubeID, usuarioID int) (bool, error) {
+	return organizaClube(ctx, r.db, clubeID, usuarioID, false)
+}
+
+// EhCriador informa se o usuário criou o clube. Retorna pgx.ErrNoRows se o clube não existir.
+func (r *pgClubeRepository) EhCriador(ctx context.Context, clubeID, usuarioID int) (bool, error) {
+	return organizaClube(ctx, r.db, clubeID, usuarioID, true)
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"competitions/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrDuplaComInscricoes é retornado ao tentar desfazer uma dupla que possui inscrições em torneios.
+var ErrDuplaComInscricoes = errors.New("a dupla possui inscrições em torneios e não pode ser desfeita")
+
+// DuplaRepository define a interface para as operações de dados de duplas.
+type DuplaRepository interface {
+	Create(ctx context.Context, input models.DuplaInput) (models.DuplaDetalhes, error)
+	FindAll(ctx context.Context, jogadorID int) ([]models.DuplaDetalhes, error)
+	FindByID(ctx context.Context, id int) (models.DuplaDetalhes, error)
+	Rename(ctx context.Context, id int, input models.RenomearDuplaInput) (int64, error)
+	Delete(ctx context.Context, id int) (int64, error)
+	IntegraDupla(ctx context.Context, duplaID, usuarioID int) (bool, error)
+	EhJogadorDoUsuario(ctx context.Context, usuarioID int, jogadorIDs ...int) (bool, error)
+}
+
+// pgDuplaRepository é a implementação concreta para DuplaRepository.
+type pgDuplaRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDuplaRepository cria uma nova instância de DuplaRepository.
+func NewDuplaRepository(db *pgxpool.Pool) DuplaRepository {
+	return &pgDuplaRepository{db: db}
+}
+
+// consultaDuplas seleciona as duplas com o ID e o nome de cada jogador.
+const consultaDuplas = `
+	SELECT d.id, d.nome_dupla, ja.id, ja.nome, jb.id, jb.nome
+	FROM duplas d
+	JOIN jogadores ja ON ja.id = d.id_jogador_a
+	JOIN jogadores jb ON jb.id = d.id_jogador_b`
+
+// scanDupla lê uma linha de consultaDuplas para um models.DuplaDetalhes.
+func scanDupla(row pgx.Row) (models.DuplaDetalhes, error) {
+	d := models.DuplaDetalhes{JogadorA: &models.JogadorDetalhes{}, JogadorB: &models.JogadorDetalhes{}}
+	err := row.Scan(&d.ID, &d.NomeDupla, &d.JogadorA.ID, &d.JogadorA.Nome, &d.JogadorB.ID, &d.JogadorB.Nome)
+	return d, err
+}
+
+// Create insere uma nova dupla, normalizando a ordem dos jogadores (id_jogador_a < id_jogador_b).
+func (r *pgDuplaRepository) Create(ctx context.Context, input models.DuplaInput) (models.DuplaDetalhes, error) {
+	jogadorA, jogadorB := input.JogadoresOrdenados()
+
+	var id int
+	query := "INSERT INTO duplas (id_jogador_a, id_jogador_b, nome_dupla) VALUES ($1, $2, $3) RETURNING id"
+	if err := r.db.QueryRow(ctx, query, jogadorA, jogadorB, input.NomeDupla).Scan(&id); err != nil {
+		return models.DuplaDetalhes{}, err
+	}
+	return r.FindByID(ctx, id)
+}
+
+// FindAll recupera as duplas cadastradas. Se jogadorID for maior que zero, retorna apenas as duplas do jogador.
+func (r *pgDuplaRepository) FindAll(ctx context.Context, jogadorID int) ([]models.DuplaDetalhes, error) {
+	query := consultaDuplas
+	var args []any
+	if jogadorID > 0 {
+		query += " WHERE d.id_jogador_a = $1 OR d.id_jogador_b = $1"
+		args = append(args, jogadorID)
+	}
+	query += " ORDER BY d.id"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	duplas := []models.DuplaDetalhes{}
+	for rows.Next() {
+		d, err := scanDupla(rows)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler dupla: %w", err)
+		}
+		duplas = append(duplas, d)
+	}
+	return duplas, rows.Err()
+}
+
+// FindByID recupera uma única dupla pelo seu ID.
+func (r *pgDuplaRepository) FindByID(ctx context.Context, id int) (models.DuplaDetalhes, error) {
+	return scanDupla(r.db.QueryRow(ctx, consultaDuplas+" WHERE d.id = $1", id))
+}
+
+// Rename altera o nome de uma dupla.
+func (r *pgDuplaRepository) Rename(ctx context.Context, id int, input models.RenomearDuplaInput) (int64, error) {
+	result, err := r.db.Exec(ctx, "UPDATE duplas SET nome_dupla = $1 WHERE id = $2", input.NomeDupla, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// Delete desfaz uma dupla que não possua inscrições em torneios.
+func (r *pgDuplaRepository) Delete(ctx context.Context, id int) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var inscrita bool
+	if err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM jogadores_torneios WHERE id_dupla = $1)", id).Scan(&inscrita); err != nil {
+		return 0, fmt.Errorf("falha ao verificar inscrições da dupla %d: %w", id, err)
+	}
+	if inscrita {
+		return 0, ErrDuplaComInscricoes
+	}
+
+	result, err := tx.Exec(ctx, "DELETE FROM duplas WHERE id = $1", id)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// IntegraDupla informa se o jogador do usuário é um dos jogadores da dupla. Retorna
+// pgx.ErrNoRows se a dupla não existir.
+func (r *pgDuplaRepository) IntegraDupla(ctx context.Context, duplaID, usuarioID int) (bool, error) {
+	var integra bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM jogadores j
+			WHERE j.id IN (d.id_jogador_a, d.id_jogador_b) AND j.id_usuario = $2)
+		FROM duplas d WHERE d.id = $1`, duplaID, usuarioID,
+	).Scan(&integra)
+	return integra, err
+}
+
+// EhJogadorDoUsuario informa se algum dos jogadores informados pertence ao usuário.
+func (r *pgDuplaRepository) EhJogadorDoUsuario(ctx context.Context, usuarioID int, jogadorIDs ...int) (bool, error) {
+	var pertence bool
+	err := r.db.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM jogadores WHERE id_usuario = $1 AND id = ANY($2))", usuarioID, jogadorIDs,
+	).Scan(&pertence)
+	return pertence, err
+}
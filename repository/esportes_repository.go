@@ -2,6 +2,7 @@ package repository
 
 import (
 	"competitions/models"
+	"competitions/rating"
 	"context"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -14,6 +15,8 @@ type EsporteRepository interface {
 	FindByID(ctx context.Context, id int) (*models.Esporte, error)
 	Update(ctx context.Context, id int, input models.EsporteInput) (int64, error)
 	Delete(ctx context.Context, id int) (int64, error)
+	FindConfiguracaoRating(ctx context.Context, id int) (models.ConfiguracaoRating, error)
+	UpdateConfiguracaoRating(ctx context.Context, id int, input models.ConfiguracaoRatingInput) (int64, error)
 }
 
 type pgEsporteRepository struct {
@@ -75,3 +78,29 @@ func (r *pgEsporteRepository) Delete(ctx context.Context, id int) (int64, error)
 	result, err := r.db.Exec(ctx, query, id)
 	return result.RowsAffected(), err
 }
+
+// FindConfiguracaoRating recupera o algoritmo e os parâmetros de rating de um esporte.
+func (r *pgEsporteRepository) FindConfiguracaoRating(ctx context.Context, id int) (models.ConfiguracaoRating, error) {
+	cfg := models.ConfiguracaoRating{EsporteID: id}
+	query := "SELECT algoritmo_rating, fator_k_rating, tau_glicko FROM esportes WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(&cfg.Algoritmo, &cfg.FatorK, &cfg.Tau)
+	return cfg, err
+}
+
+// UpdateConfiguracaoRating altera o algoritmo e os parâmetros de rating de um esporte.
+// Parâmetros omitidos assumem os valores padrão.
+func (r *pgEsporteRepository) UpdateConfiguracaoRating(ctx context.Context, id int, input models.ConfiguracaoRatingInput) (int64, error) {
+	fatorK, tau := input.FatorK, input.Tau
+	if fatorK == 0 {
+		fatorK = int(rating.FatorKPadrao)
+	}
+	if tau == 0 {
+		tau = rating.TauPadrao
+	}
+	query := "UPDATE esportes SET algoritmo_rating = $1, fator_k_rating = $2, tau_glicko = $3 WHERE id = $4"
+	result, err := r.db.Exec(ctx, query, input.Algoritmo, fatorK, tau, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
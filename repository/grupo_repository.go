@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -12,6 +13,9 @@ import (
 type GrupoRepository interface {
 	CreateGrupos(ctx context.Context, torneioID int, input models.CriarGruposInput) ([]models.GrupoComJogadores, error)
 	GetEstatisticasGrupo(ctx context.Context, grupoID int) ([]models.EstatisticasJogador, error)
+	GetClassificacaoGrupo(ctx context.Context, grupoID int) (models.ClassificacaoGrupo, error)
+	GetClassificacaoCategoria(ctx context.Context, torneioID, categoriaID int) ([]models.ClassificacaoGrupo, error)
+	EhOrganizador(ctx context.Context, grupoID, usuarioID int) (bool, error)
 }
 
 // pgGrupoRepository é a implementação concreta para GrupoRepository.
@@ -24,17 +28,40 @@ func NewGrupoRepository(db *pgxpool.Pool) GrupoRepository {
 	return &pgGrupoRepository{db: db}
 }
 
-// CreateGrupos cria grupos para um torneio e categoria, distribuindo os jogadores.
+// CreateGrupos cria grupos para um torneio e categoria, distribuindo os jogadores ou as duplas
+// inscritas na modalidade informada.
 func (r *pgGrupoRepository) CreateGrupos(ctx context.Context, torneioID int, input models.CriarGruposInput) ([]models.GrupoComJogadores, error) {
-	// 1. Buscar todos os jogadores inscritos na categoria especificada do torneio, ordenados por rating.
+	// 1. Buscar todas as inscrições da modalidade na categoria especificada do torneio, ordenadas por rating.
+	// O ID usado na distribuição é o da inscrição (jogadores_torneios), referenciado por grupo_jogadores_torneios.
+	// O rating considerado é o do esporte do torneio na modalidade; quem ainda não jogou tem o rating inicial.
+	// Em duplas, o rating da inscrição é a soma ou a média do rating dos dois jogadores.
 	queryJogadores := `
-		SELECT jt.id_jogador, s.rating
+		SELECT jt.id, COALESCE(r.rating, 1500) AS rating
 		FROM jogadores_torneios jt
-		JOIN jogadores j ON jt.id_jogador = j.id
-		JOIN scouts s ON j.id_scout = s.id
+		JOIN torneios t ON t.id = jt.id_torneio
+		LEFT JOIN ratings_jogadores r ON r.id_jogador = jt.id_jogador
+			AND r.id_esporte = t.id_esporte AND r.tipo_modalidade = jt.tipo_modalidade
 		WHERE jt.id_torneio = $1 AND jt.id_categoria = $2 AND jt.tipo_modalidade = 'simples'
-		ORDER BY s.rating DESC
+		ORDER BY rating DESC, jt.id
 	`
+	if input.Modalidade() == "duplas" {
+		ratingDupla := "ROUND((COALESCE(ra.rating, 1500) + COALESCE(rb.rating, 1500)) / 2.0)::INT"
+		if input.RatingDupla == models.RatingDuplaSoma {
+			ratingDupla = "COALESCE(ra.rating, 1500) + COALESCE(rb.rating, 1500)"
+		}
+		queryJogadores = `
+		SELECT jt.id, ` + ratingDupla + ` AS rating
+		FROM jogadores_torneios jt
+		JOIN torneios t ON t.id = jt.id_torneio
+		JOIN duplas d ON jt.id_dupla = d.id
+		LEFT JOIN ratings_jogadores ra ON ra.id_jogador = d.id_jogador_a
+			AND ra.id_esporte = t.id_esporte AND ra.tipo_modalidade = jt.tipo_modalidade
+		LEFT JOIN ratings_jogadores rb ON rb.id_jogador = d.id_jogador_b
+			AND rb.id_esporte = t.id_esporte AND rb.tipo_modalidade = jt.tipo_modalidade
+		WHERE jt.id_torneio = $1 AND jt.id_categoria = $2 AND jt.tipo_modalidade = 'duplas'
+		ORDER BY rating DESC, jt.id
+	`
+	}
 	rows, err := r.db.Query(ctx, queryJogadores, torneioID, input.CategoriaID)
 	if err != nil {
 		return nil, fmt.Errorf("falha ao buscar jogadores: %w", err)
@@ -45,10 +72,13 @@ func (r *pgGrupoRepository) CreateGrupos(ctx context.Context, torneioID int, inp
 	for rows.Next() {
 		var p models.RankedPlayer
 		if err := rows.Scan(&p.ID, &p.Rating); err != nil {
-			return nil, fmt.Errorf("falha ao ler ID e rating do jogador: %w", err)
+			return nil, fmt.Errorf("falha ao ler ID e rating da inscrição: %w", err)
 		}
 		rankedPlayers = append(rankedPlayers, p)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("falha ao ler inscrições: %w", err)
+	}
 
 	// 2. Distribuir jogadores em grupos.
 	gruposDeJogadores, err := models.DistributePlayersRanked(rankedPlayers)
@@ -65,7 +95,7 @@ func (r *pgGrupoRepository) CreateGrupos(ctx context.Context, torneioID int, inp
 
 	var gruposResult []models.GrupoComJogadores
 
-	// 4. Criar cada grupo e associar jogadores.
+	// 4. Criar cada grupo e associar as inscrições.
 	for i, grupoDeJogadores := range gruposDeJogadores {
 		// Criar o grupo no banco.
 		nomeGrupo := fmt.Sprintf("Grupo %d", i+1)
@@ -76,34 +106,49 @@ func (r *pgGrupoRepository) CreateGrupos(ctx context.Context, torneioID int, inp
 			return nil, fmt.Errorf("falha ao criar grupo '%s': %w", nomeGrupo, err)
 		}
 
-		// Associar jogadores ao grupo.
-		var jogadoresDoGrupo []models.Usuario
-		for _, jogadorID := range grupoDeJogadores {
+		// Associar as inscrições ao grupo.
+		grupo := models.GrupoComJogadores{
+			Grupo: models.Grupo{
+				ID:          grupoID,
+				TorneioID:   torneioID,
+				CategoriaID: input.CategoriaID,
+				Nome:        nomeGrupo,
+			},
+		}
+		for _, inscricaoID := range grupoDeJogadores {
 			queryAssociacao := `INSERT INTO grupo_jogadores_torneios (id_grupo, id_jogador_torneio) VALUES ($1, $2)`
-			_, err := tx.Exec(ctx, queryAssociacao, grupoID, jogadorID)
+			_, err := tx.Exec(ctx, queryAssociacao, grupoID, inscricaoID)
 			if err != nil {
-				return nil, fmt.Errorf("falha ao associar jogador %d ao grupo %d: %w", jogadorID, grupoID, err)
+				return nil, fmt.Errorf("falha ao associar inscrição %d ao grupo %d: %w", inscricaoID, grupoID, err)
+			}
+
+			// Buscar detalhes do participante para a resposta.
+			if input.Modalidade() == "duplas" {
+				dupla, err := scanDupla(tx.QueryRow(ctx, consultaDuplas+`
+					JOIN jogadores_torneios jt ON jt.id_dupla = d.id
+					WHERE jt.id = $1`, inscricaoID))
+				if err != nil {
+					return nil, fmt.Errorf("falha ao buscar detalhes da inscrição %d: %w", inscricaoID, err)
+				}
+				grupo.Duplas = append(grupo.Duplas, dupla)
+				continue
 			}
 
-			// Buscar detalhes do jogador para a resposta.
 			var jogador models.Usuario
-			queryJogador := `SELECT id, nome, email FROM usuarios WHERE id = (SELECT id_usuario FROM jogadores WHERE id = $1)`
-			err = tx.QueryRow(ctx, queryJogador, jogadorID).Scan(&jogador.ID, &jogador.Nome, &jogador.Email)
+			queryJogador := `
+				SELECT u.id, u.nome, u.email
+				FROM jogadores_torneios jt
+				JOIN jogadores j ON jt.id_jogador = j.id
+				JOIN usuarios u ON j.id_usuario = u.id
+				WHERE jt.id = $1`
+			err = tx.QueryRow(ctx, queryJogador, inscricaoID).Scan(&jogador.ID, &jogador.Nome, &jogador.Email)
 			if err != nil {
-				return nil, fmt.Errorf("falha ao buscar detalhes do jogador %d: %w", jogadorID, err)
+				return nil, fmt.Errorf("falha ao buscar detalhes da inscrição %d: %w", inscricaoID, err)
 			}
-			jogadoresDoGrupo = append(jogadoresDoGrupo, jogador)
+			grupo.Jogadores = append(grupo.Jogadores, jogador)
 		}
 
-		gruposResult = append(gruposResult, models.GrupoComJogadores{
-			Grupo: models.Grupo{
-				ID:          grupoID,
-				TorneioID:   torneioID,
-				CategoriaID: input.CategoriaID,
-				Nome:        nomeGrupo,
-			},
-			Jogadores: jogadoresDoGrupo,
-		})
+		gruposResult = append(gruposResult, grupo)
 	}
 
 	// 5. Commit da transação.
@@ -114,13 +159,18 @@ func (r *pgGrupoRepository) CreateGrupos(ctx context.Context, torneioID int, inp
 	return gruposResult, nil
 }
 
+// GetEstatisticasGrupo soma, para cada jogador de um grupo de simples, os sets e os pontos
+// conquistados por ele (apenas os do seu lado em cada jogo).
 func (r *pgGrupoRepository) GetEstatisticasGrupo(ctx context.Context, grupoID int) ([]models.EstatisticasJogador, error) {
 	query := `
 		SELECT 
 			j.id AS jogador_id,
 			u.nome AS nome_jogador,
-			COALESCE(SUM(CASE WHEN s.vencedor_set = j.id THEN 1 ELSE 0 END), 0) AS sets_ganhos,
-			COALESCE(SUM(s.pontos_jogador1 + s.pontos_jogador2), 0) AS pontos_ganhos
+			COALESCE(SUM(CASE
+				WHEN jg.id_jogador_torneio1 = jt.id AND s.pontos_jogador1 > s.pontos_jogador2 THEN 1
+				WHEN jg.id_jogador_torneio2 = jt.id AND s.pontos_jogador2 > s.pontos_jogador1 THEN 1
+				ELSE 0 END), 0) AS sets_ganhos,
+			COALESCE(SUM(CASE WHEN jg.id_jogador_torneio1 = jt.id THEN s.pontos_jogador1 ELSE s.pontos_jogador2 END), 0) AS pontos_ganhos
 		FROM grupo_jogadores_torneios g_jt
 		JOIN jogadores_torneios jt ON g_jt.id_jogador_torneio = jt.id
 		JOIN jogadores j ON jt.id_jogador = j.id
@@ -149,3 +199,162 @@ func (r *pgGrupoRepository) GetEstatisticasGrupo(ctx context.Context, grupoID in
 
 	return estatisticas, nil
 }
+
+// GetClassificacaoGrupo calcula a classificação de um grupo a partir dos jogos encerrados,
+// usando os critérios de desempate configurados no torneio.
+func (r *pgGrupoRepository) GetClassificacaoGrupo(ctx context.Context, grupoID int) (models.ClassificacaoGrupo, error) {
+	return buscarClassificacaoGrupo(ctx, r.db, grupoID)
+}
+
+// GetClassificacaoCategoria calcula a classificação de todos os grupos de uma categoria do torneio.
+func (r *pgGrupoRepository) GetClassificacaoCategoria(ctx context.Context, torneioID, categoriaID int) ([]models.ClassificacaoGrupo, error) {
+	rows, err := r.db.Query(ctx, "SELECT id FROM grupos WHERE id_torneio = $1 AND id_categoria = $2 ORDER BY id", torneioID, categoriaID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar grupos: %w", err)
+	}
+	grupoIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler grupos: %w", err)
+	}
+
+	tabelas := []models.ClassificacaoGrupo{}
+	for _, grupoID := range grupoIDs {
+		tabela, err := buscarClassificacaoGrupo(ctx, r.db, grupoID)
+		if err != nil {
+			return nil, err
+		}
+		tabelas = append(tabelas, tabela)
+	}
+	return tabelas, nil
+}
+
+// buscarClassificacaoGrupo lê os dados do grupo, verifica se todos os seus jogos foram
+// encerrados e calcula a classificação. Retorna pgx.ErrNoRows se o grupo não existir.
+func buscarClassificacaoGrupo(ctx context.Context, db consultor, grupoID int) (models.ClassificacaoGrupo, error) {
+	var c models.ClassificacaoGrupo
+	err := db.QueryRow(ctx, `
+		SELECT g.id, g.id_torneio, g.id_categoria, g.nome,
+			EXISTS (SELECT 1 FROM jogos jg WHERE jg.id_grupo = g.id)
+				AND NOT EXISTS (SELECT 1 FROM jogos jg WHERE jg.id_grupo = g.id AND jg.situacao <> 'encerrado')
+		FROM grupos g WHERE g.id = $1`, grupoID,
+	).Scan(&c.Grupo.ID, &c.Grupo.TorneioID, &c.Grupo.CategoriaID, &c.Grupo.Nome, &c.Encerrado)
+	if err != nil {
+		return c, err
+	}
+
+	c.Linhas, err = calcularClassificacaoGrupo(ctx, db, grupoID)
+	return c, err
+}
+
+// calcularClassificacaoGrupo carrega os participantes e os jogos encerrados de um grupo e
+// calcula a classificação com models.CalcularClassificacao. Retorna pgx.ErrNoRows se o
+// grupo não existir.
+func calcularClassificacaoGrupo(ctx context.Context, db consultor, grupoID int) ([]models.LinhaClassificacao, error) {
+	var criterios []string
+	err := db.QueryRow(ctx, `
+		SELECT t.criterios_desempate
+		FROM grupos g JOIN torneios t ON t.id = g.id_torneio
+		WHERE g.id = $1`, grupoID,
+	).Scan(&criterios)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT jt.id, jt.id_jogador, jt.id_dupla, COALESCE(u.nome, d.nome_dupla, ua.nome || ' / ' || ub.nome, '')
+		FROM grupo_jogadores_torneios gjt
+		JOIN jogadores_torneios jt ON jt.id = gjt.id_jogador_torneio
+		LEFT JOIN jogadores j ON j.id = jt.id_jogador
+		LEFT JOIN usuarios u ON u.id = j.id_usuario
+		LEFT JOIN duplas d ON d.id = jt.id_dupla
+		LEFT JOIN jogadores ja ON ja.id = d.id_jogador_a
+		LEFT JOIN usuarios ua ON ua.id = ja.id_usuario
+		LEFT JOIN jogadores jb ON jb.id = d.id_jogador_b
+		LEFT JOIN usuarios ub ON ub.id = jb.id_usuario
+		WHERE gjt.id_grupo = $1`, grupoID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar participantes do grupo %d: %w", grupoID, err)
+	}
+	var participantes []int
+	membros := map[int]models.LinhaClassificacao{}
+	inscricaoDaDupla := map[int]int{}
+	for rows.Next() {
+		var m models.LinhaClassificacao
+		if err := rows.Scan(&m.InscricaoID, &m.JogadorID, &m.DuplaID, &m.Nome); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("falha ao ler participante do grupo: %w", err)
+		}
+		participantes = append(participantes, m.InscricaoID)
+		membros[m.InscricaoID] = m
+		if m.DuplaID != nil {
+			inscricaoDaDupla[*m.DuplaID] = m.InscricaoID
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(ctx, `
+		SELECT jg.tipo_modalidade, jg.id_jogador_torneio1, jg.id_jogador_torneio2, jg.id_dupla1, jg.id_dupla2,
+			jg.id_jogador_vencedor, jg.id_dupla_vencedora,
+			ARRAY(SELECT s.pontos_jogador1 FROM sets s WHERE s.id_jogo = jg.id ORDER BY s.set_numero),
+			ARRAY(SELECT s.pontos_jogador2 FROM sets s WHERE s.id_jogo = jg.id ORDER BY s.set_numero)
+		FROM jogos jg
+		WHERE jg.id_grupo = $1 AND jg.situacao = 'encerrado'`, grupoID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar jogos do grupo %d: %w", grupoID, err)
+	}
+	defer rows.Close()
+
+	var partidas []models.PartidaClassificacao
+	for rows.Next() {
+		var modalidade string
+		var jt1, jt2, dupla1, dupla2, jogadorVencedor, duplaVencedora *int
+		var pontos1, pontos2 []int
+		if err := rows.Scan(&modalidade, &jt1, &jt2, &dupla1, &dupla2, &jogadorVencedor, &duplaVencedora, &pontos1, &pontos2); err != nil {
+			return nil, fmt.Errorf("falha ao ler jogo do grupo: %w", err)
+		}
+
+		var p models.PartidaClassificacao
+		if modalidade == "duplas" {
+			if dupla1 == nil || dupla2 == nil || duplaVencedora == nil {
+				continue
+			}
+			p.Participante1, p.Participante2 = inscricaoDaDupla[*dupla1], inscricaoDaDupla[*dupla2]
+			p.Vencedor = p.Participante2
+			if *duplaVencedora == *dupla1 {
+				p.Vencedor = p.Participante1
+			}
+		} else {
+			if jt1 == nil || jt2 == nil || jogadorVencedor == nil {
+				continue
+			}
+			p.Participante1, p.Participante2 = *jt1, *jt2
+			p.Vencedor = p.Participante2
+			if j := membros[*jt1].JogadorID; j != nil && *j == *jogadorVencedor {
+				p.Vencedor = p.Participante1
+			}
+		}
+		for i := range pontos1 {
+			p.Sets = append(p.Sets, models.SetInput{PontosJogador1: pontos1[i], PontosJogador2: pontos2[i]})
+		}
+		partidas = append(partidas, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	classificacao := models.CalcularClassificacao(participantes, partidas, criterios)
+	for i := range classificacao {
+		m := membros[classificacao[i].InscricaoID]
+		classificacao[i].JogadorID, classificacao[i].DuplaID, classificacao[i].Nome = m.JogadorID, m.DuplaID, m.Nome
+	}
+	return classificacao, nil
+}
+
+// EhOrganizador informa se o usuário criou ou é coorganizador do torneio do grupo. Retorna
+// pgx.ErrNoRows se o grupo não existir.
+func (r *pgGrupoRepository) EhOrganizador(ctx context.Context, grupoID, usuarioID int) (bool, error) {
+	return organizaTorneio(ctx, r.db, torneioDoGrupo, grupoID, usuarioID, false)
+}
@@ -0,0 +1,774 @@
+package repository
+
+import (
+	"competitions/models"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Erros customizados para o repositório de jogos.
+var (
+	ErrJogoEncerrado           = errors.New("o jogo já está encerrado e não pode ser alterado")
+	ErrJogoParticipantesIguais = errors.New("os dois lados do jogo devem ser participantes diferentes")
+	ErrLadoVencedorInvalido    = errors.New("o lado vencedor deve ser 1 ou 2")
+	ErrJogosJaGerados          = errors.New("os jogos deste grupo já foram gerados")
+	ErrGrupoSemParticipantes   = errors.New("o grupo precisa de pelo menos 2 participantes para gerar jogos")
+	ErrGruposNaoEncontrados    = errors.New("nenhum grupo encontrado para o torneio e categoria informados")
+	ErrResultadoComSets        = errors.New("o jogo possui sets registrados; o resultado deve ser corrigido pelo placar de sets")
+	ErrExclusaoJogoEncerrado   = errors.New("o jogo já está encerrado e não pode ser excluído; corrija o resultado em vez de excluí-lo")
+)
+
+// JogoRepository define a interface para as operações de dados de jogos (partidas).
+type JogoRepository interface {
+	Create(ctx context.Context, input models.JogoInput) (models.Jogo, error)
+	FindAll(ctx context.Context, filtro models.FiltroJogos) ([]models.Jogo, error)
+	FindByID(ctx context.Context, id int) (models.Jogo, error)
+	Update(ctx context.Context, id int, input models.JogoInput) (int64, error)
+	Delete(ctx context.Context, id int) (int64, error)
+	RegistrarResultado(ctx context.Context, id int, input models.ResultadoJogoInput) (models.Jogo, error)
+	FindSets(ctx context.Context, jogoID int) ([]models.Set, error)
+	SalvarSets(ctx context.Context, jogoID int, sets []models.SetInput) (models.PlacarJogo, error)
+	GerarJogosGrupo(ctx context.Context, grupoID int) ([]models.RodadaComJogos, error)
+	GerarJogosCategoria(ctx context.Context, torneioID, categoriaID int) ([]models.RodadaComJogos, error)
+	AgendarJogos(ctx context.Context, torneioID int, input models.AgendaInput) (models.ResultadoAgenda, error)
+	FindPlacarAoVivo(ctx context.Context, jogoID int) (models.PlacarAoVivo, error)
+	RegistrarEvento(ctx context.Context, jogoID int, input models.EventoPontuacaoInput) (models.PlacarAoVivo, error)
+	DesfazerUltimoEvento(ctx context.Context, jogoID int) (models.PlacarAoVivo, error)
+	EncerrarPlacarAoVivo(ctx context.Context, jogoID int) (models.PlacarJogo, error)
+	EhOrganizador(ctx context.Context, jogoID, usuarioID int) (bool, error)
+	EhOrganizadorTorneio(ctx context.Context, torneioID, usuarioID int) (bool, error)
+}
+
+// pgJogoRepository é a implementação concreta para JogoRepository.
+type pgJogoRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewJogoRepository cria uma nova instância de JogoRepository.
+func NewJogoRepository(db *pgxpool.Pool) JogoRepository {
+	return &pgJogoRepository{db: db}
+}
+
+// colunasJogo lista as colunas lidas por scanJogo, na mesma ordem.
+const colunasJogo = `
+	id, id_torneio, id_grupo, id_rodada, id_jogador_torneio1, id_jogador_torneio2,
+	id_dupla1, id_dupla2, id_jogador_vencedor, id_jogador_perdedor, id_dupla_vencedora,
+	id_dupla_perdedora, tipo_modalidade, data_hora, localizacao, situacao, COALESCE(eh_final_campeonato, FALSE)`
+
+// scanJogo lê uma linha com as colunas de colunasJogo para um models.Jogo.
+func scanJogo(row pgx.Row) (models.Jogo, error) {
+	var j models.Jogo
+	err := row.Scan(
+		&j.ID, &j.TorneioID, &j.GrupoID, &j.RodadaID, &j.JogadorTorneio1ID, &j.JogadorTorneio2ID,
+		&j.Dupla1ID, &j.Dupla2ID, &j.JogadorVencedorID, &j.JogadorPerdedorID, &j.DuplaVencedoraID,
+		&j.DuplaPerdedoraID, &j.TipoModalidade, &j.DataHora, &j.Localizacao, &j.Situacao, &j.EhFinalCampeonato,
+	)
+	return j, err
+}
+
+// participantesIguais verifica se os dois lados do jogo apontam para o mesmo participante.
+func participantesIguais(input models.JogoInput) bool {
+	if input.TipoModalidade == "duplas" {
+		return input.Dupla1ID != nil && input.Dupla2ID != nil && *input.Dupla1ID == *input.Dupla2ID
+	}
+	return input.JogadorTorneio1ID != nil && input.JogadorTorneio2ID != nil && *input.JogadorTorneio1ID == *input.JogadorTorneio2ID
+}
+
+// Create insere um novo jogo no banco de dados.
+func (r *pgJogoRepository) Create(ctx context.Context, input models.JogoInput) (models.Jogo, error) {
+	if participantesIguais(input) {
+		return models.Jogo{}, ErrJogoParticipantesIguais
+	}
+	return inserirJogo(ctx, r.db, input)
+}
+
+// inserirJogo insere um jogo usando o pool ou a transação fornecida e retorna a linha criada.
+func inserirJogo(ctx context.Context, db consultor, input models.JogoInput) (models.Jogo, error) {
+	situacao := input.Situacao
+	if situacao == "" {
+		situacao = "aguardando"
+	}
+
+	query := `
+		INSERT INTO jogos (id_torneio, id_grupo, id_rodada, id_jogador_torneio1, id_jogador_torneio2,
+			id_dupla1, id_dupla2, tipo_modalidade, data_hora, localizacao, situacao, eh_final_campeonato)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9, CURRENT_TIMESTAMP), $10, $11, $12)
+		RETURNING` + colunasJogo
+	return scanJogo(db.QueryRow(ctx, query,
+		input.TorneioID, input.GrupoID, input.RodadaID, input.JogadorTorneio1ID, input.JogadorTorneio2ID,
+		input.Dupla1ID, input.Dupla2ID, input.TipoModalidade, input.DataHora, input.Localizacao,
+		situacao, input.EhFinalCampeonato,
+	))
+}
+
+// Subconsultas com as inscrições e as duplas do jogador do usuário $%[1]d, usadas no filtro
+// de jogos por usuário.
+const (
+	duplasDoUsuario = `SELECT d.id FROM duplas d JOIN jogadores j ON j.id IN (d.id_jogador_a, d.id_jogador_b)
+		WHERE j.id_usuario = $%[1]d`
+	inscricoesDoUsuario = `SELECT jt.id FROM jogadores_torneios jt JOIN jogadores j ON j.id = jt.id_jogador
+		WHERE j.id_usuario = $%[1]d
+		UNION SELECT jt.id FROM jogadores_torneios jt WHERE jt.id_dupla IN (` + duplasDoUsuario + `)`
+)
+
+// FindAll recupera os jogos que atendem aos filtros informados, ordenados por data/hora.
+func (r *pgJogoRepository) FindAll(ctx context.Context, filtro models.FiltroJogos) ([]models.Jogo, error) {
+	var condicoes []string
+	var args []any
+	adicionar := func(condicao string, valor any) {
+		args = append(args, valor)
+		condicoes = append(condicoes, fmt.Sprintf(condicao, len(args)))
+	}
+	if filtro.TorneioID > 0 {
+		adicionar("id_torneio = $%d", filtro.TorneioID)
+	}
+	if filtro.GrupoID > 0 {
+		adicionar("id_grupo = $%d", filtro.GrupoID)
+	}
+	if filtro.RodadaID > 0 {
+		adicionar("id_rodada = $%d", filtro.RodadaID)
+	}
+	if filtro.Situacao != "" {
+		adicionar("situacao = $%d", filtro.Situacao)
+	}
+	if filtro.UsuarioID > 0 {
+		adicionar(`(id_jogador_torneio1 IN (`+inscricoesDoUsuario+`) OR id_jogador_torneio2 IN (`+inscricoesDoUsuario+`)
+			OR id_dupla1 IN (`+duplasDoUsuario+`) OR id_dupla2 IN (`+duplasDoUsuario+`))`, filtro.UsuarioID)
+	}
+
+	query := "SELECT" + colunasJogo + " FROM jogos"
+	if len(condicoes) > 0 {
+		query += " WHERE " + strings.Join(condicoes, " AND ")
+	}
+	query += " ORDER BY data_hora, id"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jogos := []models.Jogo{}
+	for rows.Next() {
+		jogo, err := scanJogo(rows)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler jogo: %w", err)
+		}
+		jogos = append(jogos, jogo)
+	}
+	return jogos, rows.Err()
+}
+
+// FindByID recupera um único jogo pelo seu ID.
+func (r *pgJogoRepository) FindByID(ctx context.Context, id int) (models.Jogo, error) {
+	query := "SELECT" + colunasJogo + " FROM jogos WHERE id = $1"
+	return scanJogo(r.db.QueryRow(ctx, query, id))
+}
+
+// Update modifica os dados de agendamento e participantes de um jogo ainda não encerrado.
+// O resultado é registrado exclusivamente por RegistrarResultado.
+func (r *pgJogoRepository) Update(ctx context.Context, id int, input models.JogoInput) (int64, error) {
+	if participantesIguais(input) {
+		return 0, ErrJogoParticipantesIguais
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var situacaoAtual string
+	err = tx.QueryRow(ctx, "SELECT situacao FROM jogos WHERE id = $1 FOR UPDATE", id).Scan(&situacaoAtual)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("falha ao buscar jogo %d: %w", id, err)
+	}
+	if situacaoAtual == "encerrado" {
+		return 0, ErrJogoEncerrado
+	}
+	situacao := input.Situacao
+	if situacao == "" {
+		situacao = situacaoAtual
+	}
+
+	query := `
+		UPDATE jogos
+		SET id_torneio = $1, id_grupo = $2, id_rodada = $3, id_jogador_torneio1 = $4, id_jogador_torneio2 = $5,
+			id_dupla1 = $6, id_dupla2 = $7, tipo_modalidade = $8, data_hora = COALESCE($9, data_hora),
+			localizacao = $10, situacao = $11, eh_final_campeonato = $12
+		WHERE id = $13`
+	result, err := tx.Exec(ctx, query,
+		input.TorneioID, input.GrupoID, input.RodadaID, input.JogadorTorneio1ID, input.JogadorTorneio2ID,
+		input.Dupla1ID, input.Dupla2ID, input.TipoModalidade, input.DataHora, input.Localizacao,
+		situacao, input.EhFinalCampeonato, id,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// Delete remove um jogo do banco de dados. Jogos encerrados são rejeitados com
+// ErrExclusaoJogoEncerrado: o resultado deles já atualizou os ratings, o histórico e o
+// chaveamento, que ficariam inconsistentes sem o jogo.
+func (r *pgJogoRepository) Delete(ctx context.Context, id int) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var situacao string
+	err = tx.QueryRow(ctx, "SELECT situacao FROM jogos WHERE id = $1 FOR UPDATE", id).Scan(&situacao)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("falha ao buscar jogo %d: %w", id, err)
+	}
+	if situacao == "encerrado" {
+		return 0, ErrExclusaoJogoEncerrado
+	}
+
+	result, err := tx.Exec(ctx, "DELETE FROM jogos WHERE id = $1", id)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// RegistrarResultado grava o vencedor e o perdedor de um jogo e o marca como encerrado.
+// Jogos com sets registrados são rejeitados com ErrResultadoComSets: o vencedor é derivado
+// dos sets, e gravar apenas o vencedor deixaria o placar inconsistente com o resultado.
+func (r *pgJogoRepository) RegistrarResultado(ctx context.Context, id int, input models.ResultadoJogoInput) (models.Jogo, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.Jogo{}, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Bloqueia o jogo antes de verificar os sets, para não concorrer com SalvarSets.
+	var possuiSets bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM sets s WHERE s.id_jogo = jg.id)
+		FROM jogos jg WHERE jg.id = $1
+		FOR UPDATE OF jg`, id,
+	).Scan(&possuiSets)
+	if err != nil {
+		return models.Jogo{}, err
+	}
+	if possuiSets {
+		return models.Jogo{}, ErrResultadoComSets
+	}
+
+	if err := registrarVencedor(ctx, tx, id, input.LadoVencedor); err != nil {
+		return models.Jogo{}, err
+	}
+
+	jogo, err := scanJogo(tx.QueryRow(ctx, "SELECT"+colunasJogo+" FROM jogos WHERE id = $1", id))
+	if err != nil {
+		return models.Jogo{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return models.Jogo{}, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return jogo, nil
+}
+
+// FindSets recupera os sets de um jogo, ordenados pelo número do set.
+func (r *pgJogoRepository) FindSets(ctx context.Context, jogoID int) ([]models.Set, error) {
+	return buscarSets(ctx, r.db, jogoID)
+}
+
+// SalvarSets substitui a lista completa de sets de um jogo, deriva o vencedor a partir
+// dos sets e encerra o jogo, tudo em uma única transação. O placar é validado pelas
+// regras de pontuação do esporte do torneio. Chamadas subsequentes corrigem o placar,
+// recalculando o vencedor.
+func (r *pgJogoRepository) SalvarSets(ctx context.Context, jogoID int, sets []models.SetInput) (models.PlacarJogo, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.PlacarJogo{}, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := buscarParticipantesJogo(ctx, tx, jogoID)
+	if err != nil {
+		return models.PlacarJogo{}, err
+	}
+
+	ladoVencedor, err := validarPlacarJogo(ctx, tx, jogoID, sets)
+	if err != nil {
+		return models.PlacarJogo{}, err
+	}
+
+	placar, err := gravarPlacar(ctx, tx, jogoID, p, sets, ladoVencedor)
+	if err != nil {
+		return models.PlacarJogo{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.PlacarJogo{}, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return placar, nil
+}
+
+// EhOrganizador informa se o usuário criou ou é coorganizador do torneio do jogo. Retorna
+// pgx.ErrNoRows se o jogo não existir.
+func (r *pgJogoRepository) EhOrganizador(ctx context.Context, jogoID, usuarioID int) (bool, error) {
+	return organizaTorneio(ctx, r.db, torneioDoJogo, jogoID, usuarioID, false)
+}
+
+// EhOrganizadorTorneio informa se o usuário criou ou é coorganizador do torneio, usado ao
+// criar ou mover jogos. Retorna pgx.ErrNoRows se o torneio não existir.
+func (r *pgJogoRepository) EhOrganizadorTorneio(ctx context.Context, torneioID, usuarioID int) (bool, error) {
+	return organizaTorneio(ctx, r.db, torneioPorID, torneioID, usuarioID, false)
+}
+
+// GerarJogosGrupo cria as rodadas e os jogos de um grupo no formato todos contra todos.
+func (r *pgJogoRepository) GerarJogosGrupo(ctx context.Context, grupoID int) ([]models.RodadaComJogos, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rodadas, err := gerarJogosGrupo(ctx, tx, grupoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return rodadas, nil
+}
+
+// GerarJogosCategoria cria as rodadas e os jogos de todos os grupos de uma categoria
+// de um torneio. Se algum grupo já possuir jogos, nenhum jogo é gerado.
+func (r *pgJogoRepository) GerarJogosCategoria(ctx context.Context, torneioID, categoriaID int) ([]models.RodadaComJogos, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, "SELECT id FROM grupos WHERE id_torneio = $1 AND id_categoria = $2 ORDER BY id", torneioID, categoriaID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar grupos: %w", err)
+	}
+	grupoIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler grupos: %w", err)
+	}
+	if len(grupoIDs) == 0 {
+		return nil, ErrGruposNaoEncontrados
+	}
+
+	todas := []models.RodadaComJogos{}
+	for _, grupoID := range grupoIDs {
+		rodadas, err := gerarJogosGrupo(ctx, tx, grupoID)
+		if err != nil {
+			return nil, err
+		}
+		todas = append(todas, rodadas...)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return todas, nil
+}
+
+// AgendarJogos atribui quadra e horário a todos os jogos pendentes ('aguardando') do torneio,
+// respeitando a quantidade de quadras e o período do torneio, a janela diária, a duração
+// estimada dos jogos e o descanso mínimo entre jogos de um mesmo jogador. A duração de
+// cada jogo é a do esporte, ajustada pelo formato de partida da sua categoria.
+// Jogos que não couberem no período mantêm o agendamento anterior.
+func (r *pgJogoRepository) AgendarJogos(ctx context.Context, torneioID int, input models.AgendaInput) (models.ResultadoAgenda, error) {
+	inicioDia, fimDia, err := input.Janela()
+	if err != nil {
+		return models.ResultadoAgenda{}, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.ResultadoAgenda{}, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	cfg := models.ConfiguracaoAgenda{
+		InicioDia: inicioDia,
+		FimDia:    fimDia,
+		Descanso:  time.Duration(input.DescansoMinutos) * time.Minute,
+	}
+	err = tx.QueryRow(ctx, `
+		SELECT quantidade_quadras, inicio, fim FROM torneios WHERE id = $1 FOR UPDATE`, torneioID,
+	).Scan(&cfg.Quadras, &cfg.Inicio, &cfg.Fim)
+	if err != nil {
+		return models.ResultadoAgenda{}, err
+	}
+	rt, err := carregarRegrasTorneio(ctx, tx, torneioID)
+	if err != nil {
+		return models.ResultadoAgenda{}, err
+	}
+	cfg.Duracao = rt.duracao
+	if input.DuracaoMinutos > 0 {
+		cfg.Duracao = time.Duration(input.DuracaoMinutos) * time.Minute
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT jg.id, r.numero, `+colunaCategoriaJogo+`,
+			ARRAY_REMOVE(ARRAY[jt1.id_jogador, jt2.id_jogador, d1.id_jogador_a, d1.id_jogador_b, d2.id_jogador_a, d2.id_jogador_b], NULL)
+		FROM jogos jg
+		JOIN rodadas r ON r.id = jg.id_rodada
+		LEFT JOIN jogadores_torneios jt1 ON jt1.id = jg.id_jogador_torneio1
+		LEFT JOIN jogadores_torneios jt2 ON jt2.id = jg.id_jogador_torneio2
+		LEFT JOIN duplas d1 ON d1.id = jg.id_dupla1
+		LEFT JOIN duplas d2 ON d2.id = jg.id_dupla2`+joinsCategoriaJogo+`
+		WHERE jg.id_torneio = $1 AND jg.situacao = 'aguardando'`, torneioID)
+	if err != nil {
+		return models.ResultadoAgenda{}, fmt.Errorf("falha ao buscar jogos pendentes: %w", err)
+	}
+	var jogos []models.JogoAgendavel
+	for rows.Next() {
+		var j models.JogoAgendavel
+		var rodada, categoriaID *int
+		if err := rows.Scan(&j.JogoID, &rodada, &categoriaID, &j.Jogadores); err != nil {
+			rows.Close()
+			return models.ResultadoAgenda{}, fmt.Errorf("falha ao ler jogo pendente: %w", err)
+		}
+		if rodada != nil {
+			j.Rodada = *rodada
+		}
+		if input.DuracaoMinutos == 0 {
+			j.Duracao = rt.duracaoCategoria(categoriaID)
+		}
+		jogos = append(jogos, j)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return models.ResultadoAgenda{}, err
+	}
+
+	resultado := models.AgendarJogos(jogos, cfg)
+	for _, a := range resultado.Alocacoes {
+		_, err := tx.Exec(ctx, "UPDATE jogos SET data_hora = $1, localizacao = $2 WHERE id = $3",
+			a.DataHora, fmt.Sprintf("Quadra %d", a.Quadra), a.JogoID,
+		)
+		if err != nil {
+			return models.ResultadoAgenda{}, fmt.Errorf("falha ao agendar jogo %d: %w", a.JogoID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.ResultadoAgenda{}, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return resultado, nil
+}
+
+// inscricaoGrupo representa um participante de um grupo: a inscrição e, em
+// categorias de duplas, a dupla inscrita.
+type inscricaoGrupo struct {
+	ID         int
+	Modalidade string
+	DuplaID    *int
+}
+
+// gerarJogosGrupo gera, dentro da transação fornecida, uma rodada para cada volta do
+// método do círculo e um jogo para cada confronto, de modo que todos os participantes
+// do grupo se enfrentem exatamente uma vez.
+func gerarJogosGrupo(ctx context.Context, tx pgx.Tx, grupoID int) ([]models.RodadaComJogos, error) {
+	// Bloqueia o grupo para evitar gerações concorrentes.
+	var torneioID int
+	var nomeGrupo string
+	err := tx.QueryRow(ctx, "SELECT id_torneio, nome FROM grupos WHERE id = $1 FOR UPDATE", grupoID).Scan(&torneioID, &nomeGrupo)
+	if err != nil {
+		return nil, err
+	}
+
+	var jaGerados bool
+	if err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM jogos WHERE id_grupo = $1)", grupoID).Scan(&jaGerados); err != nil {
+		return nil, fmt.Errorf("falha ao verificar jogos do grupo %d: %w", grupoID, err)
+	}
+	if jaGerados {
+		return nil, ErrJogosJaGerados
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT jt.id, jt.tipo_modalidade, jt.id_dupla
+		FROM grupo_jogadores_torneios gjt
+		JOIN jogadores_torneios jt ON jt.id = gjt.id_jogador_torneio
+		WHERE gjt.id_grupo = $1
+		ORDER BY jt.id`, grupoID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar participantes do grupo %d: %w", grupoID, err)
+	}
+	inscricoes := map[int]inscricaoGrupo{}
+	var ids []int
+	for rows.Next() {
+		var i inscricaoGrupo
+		if err := rows.Scan(&i.ID, &i.Modalidade, &i.DuplaID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("falha ao ler participante do grupo: %w", err)
+		}
+		inscricoes[i.ID] = i
+		ids = append(ids, i.ID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) < 2 {
+		return nil, ErrGrupoSemParticipantes
+	}
+
+	rodadas := []models.RodadaComJogos{}
+	for n, confrontos := range models.GerarConfrontosRoundRobin(ids) {
+		rodada := models.RodadaComJogos{Rodada: models.Rodada{
+			TorneioID: torneioID,
+			GrupoID:   &grupoID,
+			Numero:    n + 1,
+			Nome:      fmt.Sprintf("%s - Rodada %d", nomeGrupo, n+1),
+		}}
+		err := tx.QueryRow(ctx, "INSERT INTO rodadas (id_torneio, id_grupo, numero, nome) VALUES ($1, $2, $3, $4) RETURNING id",
+			rodada.TorneioID, rodada.GrupoID, rodada.Numero, rodada.Nome,
+		).Scan(&rodada.ID)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao criar rodada '%s': %w", rodada.Nome, err)
+		}
+
+		for _, confronto := range confrontos {
+			lado1, lado2 := inscricoes[confronto[0]], inscricoes[confronto[1]]
+			input := models.JogoInput{
+				TorneioID:      torneioID,
+				GrupoID:        &grupoID,
+				RodadaID:       rodada.ID,
+				TipoModalidade: lado1.Modalidade,
+			}
+			if lado1.Modalidade == "duplas" {
+				input.Dupla1ID, input.Dupla2ID = lado1.DuplaID, lado2.DuplaID
+			} else {
+				input.JogadorTorneio1ID, input.JogadorTorneio2ID = &lado1.ID, &lado2.ID
+			}
+			jogo, err := inserirJogo(ctx, tx, input)
+			if err != nil {
+				return nil, fmt.Errorf("falha ao criar jogo da rodada '%s': %w", rodada.Nome, err)
+			}
+			rodada.Jogos = append(rodada.Jogos, jogo)
+		}
+		rodadas = append(rodadas, rodada)
+	}
+	return rodadas, nil
+}
+
+// consultor é satisfeito tanto pelo pool quanto por uma transação, permitindo
+// reutilizar consultas de leitura dentro e fora de transações.
+type consultor interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// buscarSets lê os sets de um jogo ordenados pelo número do set.
+func buscarSets(ctx context.Context, db consultor, jogoID int) ([]models.Set, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, id_jogo, set_numero, pontos_jogador1, pontos_jogador2, vencedor_set
+		FROM sets WHERE id_jogo = $1 ORDER BY set_numero`, jogoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sets := []models.Set{}
+	for rows.Next() {
+		var s models.Set
+		if err := rows.Scan(&s.ID, &s.JogoID, &s.Numero, &s.PontosJogador1, &s.PontosJogador2, &s.VencedorSet); err != nil {
+			return nil, fmt.Errorf("falha ao ler set: %w", err)
+		}
+		sets = append(sets, s)
+	}
+	return sets, rows.Err()
+}
+
+// participantesJogo reúne a modalidade e os participantes de cada lado de um jogo.
+// Em jogos 'simples', Jogador1/Jogador2 são os IDs de jogadores obtidos a partir das
+// inscrições (jogadores_torneios); em jogos de 'duplas', Dupla1/Dupla2 são as duplas.
+type participantesJogo struct {
+	Modalidade string
+	Situacao   string
+	Jogador1   *int
+	Jogador2   *int
+	Dupla1     *int
+	Dupla2     *int
+	// Resultado já registrado, quando o jogo está encerrado
+	JogadorVencedor *int
+	DuplaVencedora  *int
+}
+
+// jogadorDoLado retorna o ID do jogador do lado informado em um jogo 'simples'.
+func (p participantesJogo) jogadorDoLado(lado int) *int {
+	if lado == 1 {
+		return p.Jogador1
+	}
+	return p.Jogador2
+}
+
+// vencedorMudou informa se o vencedor informado é diferente do já registrado no jogo.
+func (p participantesJogo) vencedorMudou(jogadorVencedor, duplaVencedora *int) bool {
+	igual := func(a, b *int) bool { return (a == nil && b == nil) || (a != nil && b != nil && *a == *b) }
+	return !igual(p.JogadorVencedor, jogadorVencedor) || !igual(p.DuplaVencedora, duplaVencedora)
+}
+
+// buscarParticipantesJogo carrega os participantes de um jogo, bloqueando a linha
+// do jogo até o fim da transação.
+func buscarParticipantesJogo(ctx context.Context, tx pgx.Tx, jogoID int) (participantesJogo, error) {
+	var p participantesJogo
+	query := `
+		SELECT jg.tipo_modalidade, jg.situacao, jt1.id_jogador, jt2.id_jogador, jg.id_dupla1, jg.id_dupla2,
+			jg.id_jogador_vencedor, jg.id_dupla_vencedora
+		FROM jogos jg
+		LEFT JOIN jogadores_torneios jt1 ON jt1.id = jg.id_jogador_torneio1
+		LEFT JOIN jogadores_torneios jt2 ON jt2.id = jg.id_jogador_torneio2
+		WHERE jg.id = $1
+		FOR UPDATE OF jg`
+	if err := tx.QueryRow(ctx, query, jogoID).Scan(
+		&p.Modalidade, &p.Situacao, &p.Jogador1, &p.Jogador2, &p.Dupla1, &p.Dupla2, &p.JogadorVencedor, &p.DuplaVencedora,
+	); err != nil {
+		return p, fmt.Errorf("falha ao buscar participantes do jogo %d: %w", jogoID, err)
+	}
+	return p, nil
+}
+
+// gravarPlacar substitui os sets de um jogo pelos informados e registra o lado vencedor,
+// dentro da transação fornecida, retornando o jogo e os sets gravados. O resultado é
+// enviado à transmissão ao vivo do torneio.
+func gravarPlacar(ctx context.Context, tx pgx.Tx, jogoID int, p participantesJogo, sets []models.SetInput, ladoVencedor int) (models.PlacarJogo, error) {
+	if _, err := tx.Exec(ctx, "DELETE FROM sets WHERE id_jogo = $1", jogoID); err != nil {
+		return models.PlacarJogo{}, fmt.Errorf("falha ao remover sets do jogo %d: %w", jogoID, err)
+	}
+
+	for i, s := range sets {
+		// vencedor_set referencia jogadores; em jogos de duplas o vencedor do set
+		// é deduzido dos pontos de cada lado e a coluna permanece nula.
+		var vencedorSet *int
+		if p.Modalidade == "simples" {
+			lado, _ := s.LadoVencedorSet()
+			vencedorSet = p.jogadorDoLado(lado)
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO sets (id_jogo, set_numero, pontos_jogador1, pontos_jogador2, vencedor_set)
+			VALUES ($1, $2, $3, $4, $5)`,
+			jogoID, i+1, s.PontosJogador1, s.PontosJogador2, vencedorSet,
+		)
+		if err != nil {
+			return models.PlacarJogo{}, fmt.Errorf("falha ao inserir set %d do jogo %d: %w", i+1, jogoID, err)
+		}
+	}
+
+	if err := registrarVencedor(ctx, tx, jogoID, ladoVencedor); err != nil {
+		return models.PlacarJogo{}, err
+	}
+
+	var placar models.PlacarJogo
+	var err error
+	placar.Jogo, err = scanJogo(tx.QueryRow(ctx, "SELECT"+colunasJogo+" FROM jogos WHERE id = $1", jogoID))
+	if err != nil {
+		return models.PlacarJogo{}, err
+	}
+	placar.Sets, err = buscarSets(ctx, tx, jogoID)
+	if err != nil {
+		return models.PlacarJogo{}, err
+	}
+	if err := transmitirJogo(ctx, tx, jogoID, models.TransmissaoResultado, placar); err != nil {
+		return models.PlacarJogo{}, err
+	}
+	return placar, nil
+}
+
+// registrarVencedor preenche as colunas de vencedor/perdedor de um jogo de acordo com
+// sua modalidade e o marca como encerrado, dentro da transação fornecida, atualizando o
+// rating dos jogadores e avançando o vencedor quando o jogo pertence a um chaveamento.
+// Em jogos 'simples' as colunas de resultado referenciam jogadores, por isso o ID do
+// jogador é obtido a partir da inscrição (jogadores_torneios) de cada lado; em jogos de
+// 'duplas' os IDs das duplas são usados diretamente. As colunas da outra modalidade
+// ficam nulas, conforme a constraint chk_jogo_participantes_modalidade.
+func registrarVencedor(ctx context.Context, tx pgx.Tx, jogoID, ladoVencedor int) error {
+	if ladoVencedor != 1 && ladoVencedor != 2 {
+		return ErrLadoVencedorInvalido
+	}
+
+	p, err := buscarParticipantesJogo(ctx, tx, jogoID)
+	if err != nil {
+		return err
+	}
+
+	var jogadorVencedor, jogadorPerdedor, duplaVencedora, duplaPerdedora *int
+	if p.Modalidade == "duplas" {
+		duplaVencedora, duplaPerdedora = p.Dupla1, p.Dupla2
+		if ladoVencedor == 2 {
+			duplaVencedora, duplaPerdedora = p.Dupla2, p.Dupla1
+		}
+		if duplaVencedora == nil || duplaPerdedora == nil {
+			return fmt.Errorf("jogo %d não possui as duas duplas definidas", jogoID)
+		}
+	} else {
+		jogadorVencedor, jogadorPerdedor = p.jogadorDoLado(ladoVencedor), p.jogadorDoLado(3-ladoVencedor)
+		if jogadorVencedor == nil || jogadorPerdedor == nil {
+			return fmt.Errorf("jogo %d não possui os dois jogadores definidos", jogoID)
+		}
+	}
+
+	// O trigger de scouts atualiza os jogadores ao gravar o resultado; os scouts são bloqueados
+	// antes, em ordem fixa, para evitar deadlocks com outros resultados dos mesmos jogadores.
+	vencedores, perdedores, err := jogadoresDoResultado(ctx, tx, p.Modalidade, jogadorVencedor, jogadorPerdedor, duplaVencedora, duplaPerdedora)
+	if err != nil {
+		return err
+	}
+	if err := bloquearScouts(ctx, tx, slices.Concat(vencedores, perdedores)); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE jogos
+		SET id_jogador_vencedor = $1, id_jogador_perdedor = $2, id_dupla_vencedora = $3, id_dupla_perdedora = $4,
+			situacao = 'encerrado'
+		WHERE id = $5`,
+		jogadorVencedor, jogadorPerdedor, duplaVencedora, duplaPerdedora, jogoID,
+	)
+	if err != nil {
+		return fmt.Errorf("falha ao registrar resultado do jogo %d: %w", jogoID, err)
+	}
+
+	// O rating é atualizado no primeiro registro do resultado. Uma correção que inverte o
+	// vencedor desfaz a variação registrada para o jogo e aplica o novo resultado; correções
+	// que mantêm o vencedor não alteram o rating. Vitórias e derrotas são ajustadas pelo trigger.
+	atualizarRating := p.Situacao != "encerrado"
+	if !atualizarRating && p.vencedorMudou(jogadorVencedor, duplaVencedora) {
+		if err := desfazerRatingsJogo(ctx, tx, jogoID); err != nil {
+			return err
+		}
+		atualizarRating = true
+	}
+	if atualizarRating {
+		if err := atualizarRatingsJogo(ctx, tx, jogoID, p.Modalidade, jogadorVencedor, jogadorPerdedor, duplaVencedora, duplaPerdedora); err != nil {
+			return err
+		}
+	}
+
+	// Em jogos de chaveamento, o vencedor avança automaticamente para a partida seguinte.
+	return avancarVencedorChaveamento(ctx, tx, jogoID, ladoVencedor)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// Subconsultas que obtêm o torneio de um recurso a partir do seu ID ($1), usadas em organizaTorneio.
+const (
+	torneioPorID   = "$1"
+	torneioDoJogo  = "SELECT id_torneio FROM jogos WHERE id = $1"
+	torneioDoGrupo = "SELECT id_torneio FROM grupos WHERE id = $1"
+)
+
+// organizaTorneio informa se o usuário criou ou é coorganizador do torneio obtido pela
+// subconsulta; com apenasCriador, informa somente se o criou. Retorna pgx.ErrNoRows se o
+// recurso ou o torneio não existir.
+func organizaTorneio(ctx context.Context, db consultor, subconsulta string, id, usuarioID int, apenasCriador bool) (bool, error) {
+	coorganizador := `
+		OR EXISTS (SELECT 1 FROM torneios_organizadores o WHERE o.id_torneio = t.id AND o.id_usuario = $2)`
+	if apenasCriador {
+		coorganizador = ""
+	}
+	query := fmt.Sprintf(`
+		SELECT COALESCE(t.id_usuario_criador = $2, FALSE)%s
+		FROM torneios t WHERE t.id = (%s)`, coorganizador, subconsulta)
+
+	var organiza bool
+	err := db.QueryRow(ctx, query, id, usuarioID).Scan(&organiza)
+	return organiza, err
+}
+
+// organizaClube informa se o usuário criou ou é coorganizador do clube; com apenasCriador,
+// informa somente se o criou. Retorna pgx.ErrNoRows se o clube não existir.
+func organizaClube(ctx context.Context, db consultor, clubeID, usuarioID int, apenasCriador bool) (bool, error) {
+	coorganizador := `
+		OR EXISTS (SELECT 1 FROM clubes_organizadores o WHERE o.id_clube = c.id AND o.id_usuario = $2)`
+	if apenasCriador {
+		coorganizador = ""
+	}
+	query := fmt.Sprintf(`
+		SELECT COALESCE(c.id_usuario_criador = $2, FALSE)%s
+		FROM clubes c WHERE c.id = $1`, coorganizador)
+
+	var organiza bool
+	err := db.QueryRow(ctx, query, clubeID, usuarioID).Scan(&organiza)
+	return organiza, err
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"competitions/models"
+	"competitions/regras"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Erros da pontuação ao vivo.
+var (
+	ErrEsporteSemRegras    = errors.New("o esporte do jogo não possui regras de pontuação para o placar ao vivo")
+	ErrSemEventosPontuacao = errors.New("o jogo não possui eventos de pontuação para desfazer")
+	ErrPartidaNaoDecidida  = errors.New("a partida ainda não está decidida pelo placar ao vivo")
+)
+
+// FindPlacarAoVivo calcula o placar ao vivo de um jogo reproduzindo seus eventos de pontuação.
+func (r *pgJogoRepository) FindPlacarAoVivo(ctx context.Context, jogoID int) (models.PlacarAoVivo, error) {
+	var situacao string
+	if err := r.db.QueryRow(ctx, "SELECT situacao FROM jogos WHERE id = $1", jogoID).Scan(&situacao); err != nil {
+		return models.PlacarAoVivo{}, fmt.Errorf("falha ao buscar jogo %d: %w", jogoID, err)
+	}
+	regra, estado, eventos, err := reproduzirEventos(ctx, r.db, jogoID)
+	if err != nil {
+		return models.PlacarAoVivo{}, err
+	}
+	return models.NovoPlacarAoVivo(jogoID, situacao, regra, estado, eventos), nil
+}
+
+// RegistrarEvento acrescenta um evento à pontuação ao vivo de um jogo e retorna o placar
+// atualizado. O primeiro evento coloca o jogo 'em andamento'; eventos após a partida estar
+// decidida são rejeitados com regras.ErrPartidaEncerrada.
+func (r *pgJogoRepository) RegistrarEvento(ctx context.Context, jogoID int, input models.EventoPontuacaoInput) (models.PlacarAoVivo, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.PlacarAoVivo{}, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := buscarParticipantesJogo(ctx, tx, jogoID)
+	if err != nil {
+		return models.PlacarAoVivo{}, err
+	}
+	if p.Situacao == "encerrado" {
+		return models.PlacarAoVivo{}, ErrJogoEncerrado
+	}
+
+	regra, estado, eventos, err := reproduzirEventos(ctx, tx, jogoID)
+	if err != nil {
+		return models.PlacarAoVivo{}, err
+	}
+	if err := regra.Registrar(&estado, input.Evento()); err != nil {
+		return models.PlacarAoVivo{}, err
+	}
+
+	lado := input.Lado
+	if input.Tipo == regras.EventoLet {
+		lado = nil
+	}
+	var ev models.EventoPontuacao
+	err = tx.QueryRow(ctx, `
+		INSERT INTO eventos_pontuacao (id_jogo, tipo, lado)
+		VALUES ($1, $2, $3)
+		RETURNING id, id_jogo, tipo, lado, criado_em`,
+		jogoID, input.Tipo, lado,
+	).Scan(&ev.ID, &ev.JogoID, &ev.Tipo, &ev.Lado, &ev.CriadoEm)
+	if err != nil {
+		return models.PlacarAoVivo{}, fmt.Errorf("falha ao registrar evento do jogo %d: %w", jogoID, err)
+	}
+	eventos = append(eventos, ev)
+
+	situacao := p.Situacao
+	if situacao == "aguardando" {
+		situacao = "em andamento"
+		if _, err := tx.Exec(ctx, "UPDATE jogos SET situacao = 'em andamento' WHERE id = $1", jogoID); err != nil {
+			return models.PlacarAoVivo{}, fmt.Errorf("falha ao iniciar o jogo %d: %w", jogoID, err)
+		}
+	}
+
+	placar := models.NovoPlacarAoVivo(jogoID, situacao, regra, estado, eventos)
+	if err := transmitirJogo(ctx, tx, jogoID, models.TransmissaoPlacar, placar); err != nil {
+		return models.PlacarAoVivo{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.PlacarAoVivo{}, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return placar, nil
+}
+
+// DesfazerUltimoEvento remove o último evento da pontuação ao vivo de um jogo ainda não
+// encerrado e retorna o placar recalculado.
+func (r *pgJogoRepository) DesfazerUltimoEvento(ctx context.Context, jogoID int) (models.PlacarAoVivo, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.PlacarAoVivo{}, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := buscarParticipantesJogo(ctx, tx, jogoID)
+	if err != nil {
+		return models.PlacarAoVivo{}, err
+	}
+	if p.Situacao == "encerrado" {
+		return models.PlacarAoVivo{}, ErrJogoEncerrado
+	}
+
+	cmd, err := tx.Exec(ctx, `
+		DELETE FROM eventos_pontuacao
+		WHERE id = (SELECT MAX(id) FROM eventos_pontuacao WHERE id_jogo = $1)`, jogoID)
+	if err != nil {
+		return models.PlacarAoVivo{}, fmt.Errorf("falha ao desfazer evento do jogo %d: %w", jogoID, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.PlacarAoVivo{}, ErrSemEventosPontuacao
+	}
+
+	regra, estado, eventos, err := reproduzirEventos(ctx, tx, jogoID)
+	if err != nil {
+		return models.PlacarAoVivo{}, err
+	}
+
+	placar := models.NovoPlacarAoVivo(jogoID, p.Situacao, regra, estado, eventos)
+	if err := transmitirJogo(ctx, tx, jogoID, models.TransmissaoPlacar, placar); err != nil {
+		return models.PlacarAoVivo{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.PlacarAoVivo{}, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return placar, nil
+}
+
+// EncerrarPlacarAoVivo grava os sets calculados pelo placar ao vivo e encerra o jogo com o
+// vencedor da partida, da mesma forma que SalvarSets. A partida precisa estar decidida.
+func (r *pgJogoRepository) EncerrarPlacarAoVivo(ctx context.Context, jogoID int) (models.PlacarJogo, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.PlacarJogo{}, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := buscarParticipantesJogo(ctx, tx, jogoID)
+	if err != nil {
+		return models.PlacarJogo{}, err
+	}
+	if p.Situacao == "encerrado" {
+		return models.PlacarJogo{}, ErrJogoEncerrado
+	}
+
+	_, estado, _, err := reproduzirEventos(ctx, tx, jogoID)
+	if err != nil {
+		return models.PlacarJogo{}, err
+	}
+	if estado.Vencedor == 0 {
+		return models.PlacarJogo{}, ErrPartidaNaoDecidida
+	}
+
+	placar, err := gravarPlacar(ctx, tx, jogoID, p, models.SetsDoPlacar(estado.Sets), estado.Vencedor)
+	if err != nil {
+		return models.PlacarJogo{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.PlacarJogo{}, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return placar, nil
+}
+
+// reproduzirEventos lê os eventos de pontuação de um jogo e calcula o placar pelas regras
+// do esporte, considerando o formato de partida do torneio e da categoria. Retorna
+// ErrEsporteSemRegras quando o esporte não possui regras de pontuação.
+func reproduzirEventos(ctx context.Context, db consultor, jogoID int) (regras.Regra, regras.Estado, []models.EventoPontuacao, error) {
+	regra, definida, err := regraJogo(ctx, db, jogoID)
+	if err != nil {
+		return regra, regras.Estado{}, nil, err
+	}
+	if !definida {
+		return regra, regras.Estado{}, nil, ErrEsporteSemRegras
+	}
+	eventos, err := buscarEventosPontuacao(ctx, db, jogoID)
+	if err != nil {
+		return regra, regras.Estado{}, nil, err
+	}
+
+	sequencia := make([]regras.Evento, len(eventos))
+	for i, ev := range eventos {
+		sequencia[i] = regras.Evento{Tipo: ev.Tipo}
+		if ev.Lado != nil {
+			sequencia[i].Lado = *ev.Lado
+		}
+	}
+	estado, err := regra.Reproduzir(sequencia)
+	if err != nil {
+		return regra, estado, eventos, fmt.Errorf("falha ao reproduzir eventos do jogo %d: %w", jogoID, err)
+	}
+	return regra, estado, eventos, nil
+}
+
+// buscarEventosPontuacao lê os eventos de pontuação de um jogo na ordem em que foram registrados.
+func buscarEventosPontuacao(ctx context.Context, db consultor, jogoID int) ([]models.EventoPontuacao, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, id_jogo, tipo, lado, criado_em
+		FROM eventos_pontuacao WHERE id_jogo = $1 ORDER BY id`, jogoID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar eventos do jogo %d: %w", jogoID, err)
+	}
+	defer rows.Close()
+
+	eventos := []models.EventoPontuacao{}
+	for rows.Next() {
+		var ev models.EventoPontuacao
+		if err := rows.Scan(&ev.ID, &ev.JogoID, &ev.Tipo, &ev.Lado, &ev.CriadoEm); err != nil {
+			return nil, fmt.Errorf("falha ao ler evento de pontuação: %w", err)
+		}
+		eventos = append(eventos, ev)
+	}
+	return eventos, rows.Err()
+}
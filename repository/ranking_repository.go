@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"competitions/models"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RankingRepository define a interface para as consultas de ranking de jogadores.
+type RankingRepository interface {
+	FindRanking(ctx context.Context, filtro models.FiltroRanking) (models.Ranking, error)
+}
+
+// pgRankingRepository é a implementação concreta para RankingRepository.
+type pgRankingRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRankingRepository cria uma nova instância de RankingRepository.
+func NewRankingRepository(db *pgxpool.Pool) RankingRepository {
+	return &pgRankingRepository{db: db}
+}
+
+// FindRanking retorna uma página do ranking de um esporte e modalidade, ordenado pelo rating.
+// A posição anterior de cada jogador é calculada com o último rating registrado no histórico
+// antes do início do período, entre os mesmos jogadores filtrados. Total é a quantidade de
+// jogadores no ranking (zero em páginas além da última).
+func (r *pgRankingRepository) FindRanking(ctx context.Context, filtro models.FiltroRanking) (models.Ranking, error) {
+	filtro.AplicarPadroes()
+	referencia := time.Now().AddDate(0, 0, -filtro.PeriodoDias)
+
+	args := []any{filtro.EsporteID, filtro.TipoModalidade, referencia}
+	var condicoes []string
+	adicionar := func(condicao string, valor any) {
+		args = append(args, valor)
+		condicoes = append(condicoes, fmt.Sprintf(condicao, len(args)))
+	}
+	if filtro.Sexo != "" {
+		adicionar("j.sexo = $%d", filtro.Sexo)
+	}
+	if filtro.IdadeMinima > 0 {
+		adicionar("DATE_PART('year', AGE(j.data_nascimento)) >= $%d", filtro.IdadeMinima)
+	}
+	if filtro.IdadeMaxima > 0 {
+		adicionar("DATE_PART('year', AGE(j.data_nascimento)) <= $%d", filtro.IdadeMaxima)
+	}
+	if filtro.EstadoID > 0 {
+		adicionar(`EXISTS (SELECT 1 FROM clubes_usuarios cu JOIN clubes c ON c.id = cu.id_clube
+			WHERE cu.id_jogador = j.id AND c.id_estado = $%d)`, filtro.EstadoID)
+	}
+	if filtro.CidadeID > 0 {
+		adicionar(`EXISTS (SELECT 1 FROM clubes_usuarios cu JOIN clubes c ON c.id = cu.id_clube
+			WHERE cu.id_jogador = j.id AND c.id_cidade = $%d)`, filtro.CidadeID)
+	}
+	if filtro.ClubeID > 0 {
+		adicionar("EXISTS (SELECT 1 FROM clubes_usuarios cu WHERE cu.id_jogador = j.id AND cu.id_clube = $%d)", filtro.ClubeID)
+	}
+	where := ""
+	if len(condicoes) > 0 {
+		where = " AND " + strings.Join(condicoes, " AND ")
+	}
+
+	args = append(args, filtro.Limit, (filtro.Page-1)*filtro.Limit)
+	query := `
+		WITH elegiveis AS (
+			SELECT j.id, j.nome, j.sexo, DATE_PART('year', AGE(j.data_nascimento))::INT AS idade, r.rating, r.jogos
+			FROM ratings_jogadores r
+			JOIN jogadores j ON j.id = r.id_jogador
+			WHERE r.id_esporte = $1 AND r.tipo_modalidade = $2 AND j.ativo` + where + `
+		),
+		atual AS (
+			SELECT e.*, RANK() OVER (ORDER BY e.rating DESC) AS posicao
+			FROM elegiveis e
+		),
+		anterior AS (
+			SELECT e.id, RANK() OVER (ORDER BY h.rating_depois DESC) AS posicao
+			FROM elegiveis e
+			JOIN LATERAL (
+				SELECT hr.rating_depois
+				FROM historico_ratings hr
+				WHERE hr.id_jogador = e.id AND hr.id_esporte = $1 AND hr.tipo_modalidade = $2 AND hr.data_jogo < $3
+				ORDER BY hr.data_jogo DESC, hr.id DESC
+				LIMIT 1
+			) h ON TRUE
+		)
+		SELECT a.posicao, a.id, a.nome, a.sexo, a.idade, a.rating, a.jogos, ant.posicao, COUNT(*) OVER ()
+		FROM atual a
+		LEFT JOIN anterior ant ON ant.id = a.id
+		ORDER BY a.posicao, a.nome, a.id
+		LIMIT $` + fmt.Sprint(len(args)-1) + ` OFFSET $` + fmt.Sprint(len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return models.Ranking{}, fmt.Errorf("falha ao buscar ranking: %w", err)
+	}
+	defer rows.Close()
+
+	ranking := models.Ranking{
+		EsporteID:      filtro.EsporteID,
+		TipoModalidade: filtro.TipoModalidade,
+		Referencia:     referencia,
+		Page:           filtro.Page,
+		Limit:          filtro.Limit,
+		Linhas:         []models.LinhaRanking{},
+	}
+	for rows.Next() {
+		var l models.LinhaRanking
+		if err := rows.Scan(&l.Posicao, &l.JogadorID, &l.Nome, &l.Sexo, &l.Idade, &l.Rating, &l.Jogos, &l.PosicaoAnterior, &ranking.Total); err != nil {
+			return models.Ranking{}, fmt.Errorf("falha ao ler linha do ranking: %w", err)
+		}
+		if l.PosicaoAnterior != nil {
+			movimento := *l.PosicaoAnterior - l.Posicao
+			l.Movimento = &movimento
+		}
+		ranking.Linhas = append(ranking.Linhas, l)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Ranking{}, err
+	}
+	return ranking, nil
+}
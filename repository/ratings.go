@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"competitions/rating"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// configuracaoRatingJogo lê o esporte do torneio de um jogo e o seu algoritmo e parâmetros de rating.
+func configuracaoRatingJogo(ctx context.Context, db consultor, jogoID int) (int, rating.Configuracao, error) {
+	var esporteID, fatorK int
+	var cfg rating.Configuracao
+	err := db.QueryRow(ctx, `
+		SELECT e.id, e.algoritmo_rating, e.fator_k_rating, e.tau_glicko
+		FROM jogos jg
+		JOIN torneios t ON t.id = jg.id_torneio
+		JOIN esportes e ON e.id = t.id_esporte
+		WHERE jg.id = $1`, jogoID,
+	).Scan(&esporteID, &cfg.Algoritmo, &fatorK, &cfg.Tau)
+	if err != nil {
+		return 0, cfg, fmt.Errorf("falha ao buscar configuração de rating do jogo %d: %w", jogoID, err)
+	}
+	cfg.FatorK = float64(fatorK)
+	return esporteID, cfg, nil
+}
+
+// jogadoresDupla retorna os IDs dos dois jogadores de uma dupla.
+func jogadoresDupla(ctx context.Context, db consultor, duplaID int) ([]int, error) {
+	var a, b int
+	if err := db.QueryRow(ctx, "SELECT id_jogador_a, id_jogador_b FROM duplas WHERE id = $1", duplaID).Scan(&a, &b); err != nil {
+		return nil, fmt.Errorf("falha ao buscar jogadores da dupla %d: %w", duplaID, err)
+	}
+	return []int{a, b}, nil
+}
+
+// jogadoresDoResultado retorna os IDs dos jogadores vencedores e perdedores de um jogo.
+func jogadoresDoResultado(ctx context.Context, db consultor, modalidade string, jogadorVencedor, jogadorPerdedor, duplaVencedora, duplaPerdedora *int) ([]int, []int, error) {
+	if modalidade != "duplas" {
+		return []int{*jogadorVencedor}, []int{*jogadorPerdedor}, nil
+	}
+	vencedores, err := jogadoresDupla(ctx, db, *duplaVencedora)
+	if err != nil {
+		return nil, nil, err
+	}
+	perdedores, err := jogadoresDupla(ctx, db, *duplaPerdedora)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vencedores, perdedores, nil
+}
+
+// bloquearScouts bloqueia os scouts dos jogadores até o fim da transação, em ordem crescente
+// de ID. O trigger de scouts e o cálculo de rating atualizam vencedores antes dos perdedores;
+// sem uma ordem fixa, dois resultados com jogadores em comum em lados opostos podem bloquear
+// um ao outro (deadlock). Deve ser chamada antes de gravar o resultado do jogo.
+func bloquearScouts(ctx context.Context, tx pgx.Tx, jogadores []int) error {
+	_, err := tx.Exec(ctx, `
+		SELECT s.id FROM scouts s
+		WHERE s.id IN (SELECT id_scout FROM jogadores WHERE id = ANY($1))
+		ORDER BY s.id
+		FOR UPDATE`, jogadores,
+	)
+	if err != nil {
+		return fmt.Errorf("falha ao bloquear scouts dos jogadores: %w", err)
+	}
+	return nil
+}
+
+// bloquearRatings cria o rating inicial dos jogadores que ainda não têm e bloqueia o rating
+// de todos no esporte e na modalidade até o fim da transação, em ordem crescente de ID do
+// jogador, pelo mesmo motivo de bloquearScouts.
+func bloquearRatings(ctx context.Context, tx pgx.Tx, esporteID int, modalidade string, jogadores []int) error {
+	ordenados := slices.Sorted(slices.Values(jogadores))
+	for _, id := range ordenados {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO ratings_jogadores (id_jogador, id_esporte, tipo_modalidade)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING`, id, esporteID, modalidade,
+		)
+		if err != nil {
+			return fmt.Errorf("falha ao criar rating do jogador %d: %w", id, err)
+		}
+	}
+
+	_, err := tx.Exec(ctx, `
+		SELECT id_jogador FROM ratings_jogadores
+		WHERE id_jogador = ANY($1) AND id_esporte = $2 AND tipo_modalidade = $3
+		ORDER BY id_jogador
+		FOR UPDATE`, ordenados, esporteID, modalidade,
+	)
+	if err != nil {
+		return fmt.Errorf("falha ao bloquear ratings dos jogadores: %w", err)
+	}
+	return nil
+}
+
+// buscarEstadosRating carrega o rating atual dos jogadores no esporte e na modalidade. As
+// linhas devem ter sido criadas e bloqueadas antes por bloquearRatings.
+func buscarEstadosRating(ctx context.Context, tx pgx.Tx, esporteID int, modalidade string, jogadores []int) ([]rating.Jogador, error) {
+	estados := make([]rating.Jogador, len(jogadores))
+	for i, id := range jogadores {
+		var r int
+		err := tx.QueryRow(ctx, `
+			SELECT rating, desvio_rating, volatilidade
+			FROM ratings_jogadores
+			WHERE id_jogador = $1 AND id_esporte = $2 AND tipo_modalidade = $3`, id, esporteID, modalidade,
+		).Scan(&r, &estados[i].Desvio, &estados[i].Volatilidade)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao buscar rating do jogador %d: %w", id, err)
+		}
+		estados[i].Rating = float64(r)
+	}
+	return estados, nil
+}
+
+// salvarEstadosRating grava o novo rating dos jogadores no esporte e na modalidade.
+func salvarEstadosRating(ctx context.Context, tx pgx.Tx, esporteID int, modalidade string, jogadores []int, estados []rating.Jogador) error {
+	for i, id := range jogadores {
+		_, err := tx.Exec(ctx, `
+			UPDATE ratings_jogadores
+			SET rating = $1, desvio_rating = $2, volatilidade = $3, jogos = jogos + 1, atualizado_em = CURRENT_TIMESTAMP
+			WHERE id_jogador = $4 AND id_esporte = $5 AND tipo_modalidade = $6`,
+			int(math.Round(estados[i].Rating)), estados[i].Desvio, estados[i].Volatilidade, id, esporteID, modalidade,
+		)
+		if err != nil {
+			return fmt.Errorf("falha ao atualizar rating do jogador %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// atualizarRatingsJogo recalcula o rating dos jogadores de um jogo encerrado, no esporte
+// do torneio e na modalidade do jogo, com o algoritmo configurado para o esporte.
+func atualizarRatingsJogo(ctx context.Context, tx pgx.Tx, jogoID int, modalidade string, jogadorVencedor, jogadorPerdedor, duplaVencedora, duplaPerdedora *int) error {
+	esporteID, cfg, err := configuracaoRatingJogo(ctx, tx, jogoID)
+	if err != nil {
+		return err
+	}
+	vencedores, perdedores, err := jogadoresDoResultado(ctx, tx, modalidade, jogadorVencedor, jogadorPerdedor, duplaVencedora, duplaPerdedora)
+	if err != nil {
+		return err
+	}
+
+	if err := bloquearRatings(ctx, tx, esporteID, modalidade, slices.Concat(vencedores, perdedores)); err != nil {
+		return err
+	}
+	estadosVencedores, err := buscarEstadosRating(ctx, tx, esporteID, modalidade, vencedores)
+	if err != nil {
+		return err
+	}
+	estadosPerdedores, err := buscarEstadosRating(ctx, tx, esporteID, modalidade, perdedores)
+	if err != nil {
+		return err
+	}
+
+	novosVencedores, novosPerdedores, err := cfg.AtualizarPartida(estadosVencedores, estadosPerdedores)
+	if err != nil {
+		return fmt.Errorf("falha ao calcular rating do jogo %d: %w", jogoID, err)
+	}
+	if err := salvarEstadosRating(ctx, tx, esporteID, modalidade, vencedores, novosVencedores); err != nil {
+		return err
+	}
+	if err := salvarEstadosRating(ctx, tx, esporteID, modalidade, perdedores, novosPerdedores); err != nil {
+		return err
+	}
+
+	// Histórico: cada lado registra como adversário o outro lado e o seu rating médio antes do jogo.
+	ladoVencedor := ladoHistorico{jogadores: vencedores, antes: estadosVencedores, depois: novosVencedores, jogador: jogadorVencedor, dupla: duplaVencedora}
+	ladoPerdedor := ladoHistorico{jogadores: perdedores, antes: estadosPerdedores, depois: novosPerdedores, jogador: jogadorPerdedor, dupla: duplaPerdedora}
+	if err := registrarHistoricoRating(ctx, tx, jogoID, esporteID, modalidade, ladoVencedor, ladoPerdedor); err != nil {
+		return err
+	}
+	return registrarHistoricoRating(ctx, tx, jogoID, esporteID, modalidade, ladoPerdedor, ladoVencedor)
+}
+
+// desfazerRatingsJogo reverte a variação de rating registrada no histórico de um jogo e
+// remove esse histórico, para que uma correção do resultado possa ser aplicada no lugar.
+// Apenas o rating e o número de jogos são revertidos: o histórico não guarda o desvio e a
+// volatilidade anteriores do Glicko-2, que só voltam a ser exatos com o recálculo completo.
+func desfazerRatingsJogo(ctx context.Context, tx pgx.Tx, jogoID int) error {
+	_, err := tx.Exec(ctx, `
+		SELECT r.id_jogador
+		FROM ratings_jogadores r
+		JOIN historico_ratings h ON r.id_jogador = h.id_jogador
+			AND r.id_esporte = h.id_esporte AND r.tipo_modalidade = h.tipo_modalidade
+		WHERE h.id_jogo = $1
+		ORDER BY r.id_jogador
+		FOR UPDATE OF r`, jogoID,
+	)
+	if err != nil {
+		return fmt.Errorf("falha ao bloquear ratings do jogo %d: %w", jogoID, err)
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE ratings_jogadores r
+		SET rating = r.rating - h.delta, jogos = GREATEST(r.jogos - 1, 0), atualizado_em = CURRENT_TIMESTAMP
+		FROM historico_ratings h
+		WHERE h.id_jogo = $1 AND r.id_jogador = h.id_jogador
+			AND r.id_esporte = h.id_esporte AND r.tipo_modalidade = h.tipo_modalidade`, jogoID,
+	)
+	if err != nil {
+		return fmt.Errorf("falha ao reverter ratings do jogo %d: %w", jogoID, err)
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM historico_ratings WHERE id_jogo = $1", jogoID); err != nil {
+		return fmt.Errorf("falha ao remover histórico de rating do jogo %d: %w", jogoID, err)
+	}
+	return nil
+}
+
+// ladoHistorico reúne os jogadores de um lado do jogo e o seu rating antes e depois do resultado.
+type ladoHistorico struct {
+	jogadores []int
+	antes     []rating.Jogador
+	depois    []rating.Jogador
+	jogador   *int // Jogo de simples
+	dupla     *int // Jogo de duplas
+}
+
+// ratingMedio retorna o rating médio do lado antes do jogo, arredondado.
+func (l ladoHistorico) ratingMedio() int {
+	var soma float64
+	for _, e := range l.antes {
+		soma += e.Rating
+	}
+	return int(math.Round(soma / float64(len(l.antes))))
+}
+
+// registrarHistoricoRating grava a variação de rating de cada jogador de um lado do jogo.
+func registrarHistoricoRating(ctx context.Context, tx pgx.Tx, jogoID, esporteID int, modalidade string, lado, adversario ladoHistorico) error {
+	for i, id := range lado.jogadores {
+		antes, depois := int(math.Round(lado.antes[i].Rating)), int(math.Round(lado.depois[i].Rating))
+		_, err := tx.Exec(ctx, `
+			INSERT INTO historico_ratings (
+				id_jogador, id_esporte, tipo_modalidade, id_jogo, data_jogo,
+				rating_antes, rating_depois, delta, id_jogador_adversario, id_dupla_adversaria, rating_adversario
+			)
+			SELECT $1, $2, $3, id, data_hora, $4, $5, $6, $7, $8, $9 FROM jogos WHERE id = $10`,
+			id, esporteID, modalidade, antes, depois, depois-antes, adversario.jogador, adversario.dupla, adversario.ratingMedio(), jogoID,
+		)
+		if err != nil {
+			return fmt.Errorf("falha ao registrar histórico de rating do jogador %d: %w", id, err)
+		}
+	}
+	return nil
+}
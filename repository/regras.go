@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"competitions/models"
+	"competitions/regras"
+	"context"
+	"fmt"
+	"time"
+)
+
+// regrasTorneio reúne as regras oficiais do esporte de um torneio e os formatos de partida
+// configurados para ele, indexados pela categoria (zero é o formato geral do torneio).
+type regrasTorneio struct {
+	padrao   regras.Regra
+	definida bool // Falso quando o esporte não possui regras de pontuação.
+	duracao  time.Duration
+	formatos map[int]models.FormatoPartidaCampos
+}
+
+// carregarRegrasTorneio lê o esporte, a duração estimada das partidas e os formatos de
+// partida de um torneio.
+func carregarRegrasTorneio(ctx context.Context, db consultor, torneioID int) (regrasTorneio, error) {
+	var rt regrasTorneio
+	var esporte string
+	var duracao int
+	err := db.QueryRow(ctx, `
+		SELECT e.nome::text, e.duracao_estimada_minutos
+		FROM torneios t JOIN esportes e ON e.id = t.id_esporte
+		WHERE t.id = $1`, torneioID,
+	).Scan(&esporte, &duracao)
+	if err != nil {
+		return rt, fmt.Errorf("falha ao buscar esporte do torneio %d: %w", torneioID, err)
+	}
+	rt.padrao, rt.definida = regras.DoEsporte(esporte)
+	rt.duracao = time.Duration(duracao) * time.Minute
+
+	formatos, err := buscarFormatosPartida(ctx, db, torneioID)
+	if err != nil {
+		return rt, err
+	}
+	rt.formatos = make(map[int]models.FormatoPartidaCampos, len(formatos))
+	for _, f := range formatos {
+		categoria := 0
+		if f.CategoriaID != nil {
+			categoria = *f.CategoriaID
+		}
+		rt.formatos[categoria] = f.FormatoPartidaCampos
+	}
+	return rt, nil
+}
+
+// daCategoria retorna a regra das partidas de uma categoria: a regra oficial do esporte,
+// com o formato geral do torneio e, em seguida, o da categoria aplicados por cima.
+func (rt regrasTorneio) daCategoria(categoriaID *int) regras.Regra {
+	r := rt.padrao
+	if f, ok := rt.formatos[0]; ok {
+		r = r.Aplicar(f.Formato())
+	}
+	if categoriaID != nil {
+		if f, ok := rt.formatos[*categoriaID]; ok {
+			r = r.Aplicar(f.Formato())
+		}
+	}
+	return r
+}
+
+// duracaoCategoria estima a duração das partidas de uma categoria a partir da duração
+// do esporte, ajustada pelo formato configurado.
+func (rt regrasTorneio) duracaoCategoria(categoriaID *int) time.Duration {
+	if !rt.definida {
+		return rt.duracao
+	}
+	return rt.daCategoria(categoriaID).DuracaoEstimada(rt.padrao, rt.duracao)
+}
+
+// colunaCategoriaJogo é a expressão SQL da categoria de um jogo: a do grupo, a da chave ou
+// a da inscrição do primeiro lado. Exige os aliases usados em joinsCategoriaJogo.
+const (
+	colunaCategoriaJogo = "COALESCE(gc.id_categoria, chc.id_categoria, jtc.id_categoria)"
+	joinsCategoriaJogo  = `
+		LEFT JOIN grupos gc ON gc.id = jg.id_grupo
+		LEFT JOIN chaveamento_partidas cpc ON cpc.id_jogo = jg.id
+		LEFT JOIN chaveamentos chc ON chc.id = cpc.id_chaveamento
+		LEFT JOIN jogadores_torneios jtc ON jtc.id = jg.id_jogador_torneio1`
+)
+
+// regraJogo retorna as regras de pontuação de um jogo, considerando o formato de partida
+// do torneio e da categoria do jogo. O segundo retorno é falso quando o esporte não
+// possui regras definidas.
+func regraJogo(ctx context.Context, db consultor, jogoID int) (regras.Regra, bool, error) {
+	var torneioID int
+	var categoriaID *int
+	err := db.QueryRow(ctx, "SELECT jg.id_torneio, "+colunaCategoriaJogo+" FROM jogos jg"+joinsCategoriaJogo+" WHERE jg.id = $1", jogoID).
+		Scan(&torneioID, &categoriaID)
+	if err != nil {
+		return regras.Regra{}, false, fmt.Errorf("falha ao buscar torneio do jogo %d: %w", jogoID, err)
+	}
+
+	rt, err := carregarRegrasTorneio(ctx, db, torneioID)
+	if err != nil {
+		return regras.Regra{}, false, err
+	}
+	return rt.daCategoria(categoriaID), rt.definida, nil
+}
+
+// validarPlacarJogo valida os sets de um jogo pelas regras do esporte e retorna o lado
+// vencedor. Esportes sem regras definidas apenas exigem que cada set tenha um vencedor e
+// que um dos lados vença mais sets.
+func validarPlacarJogo(ctx context.Context, db consultor, jogoID int, sets []models.SetInput) (int, error) {
+	regra, ok, err := regraJogo(ctx, db, jogoID)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return models.LadoVencedorJogo(sets)
+	}
+
+	placar := make([]regras.Set, len(sets))
+	for i, s := range sets {
+		placar[i] = regras.Set{Lado1: s.PontosJogador1, Lado2: s.PontosJogador2}
+	}
+	return regra.ValidarPartida(placar)
+}
+
+// buscarFormatosPartida lê os formatos de partida de um torneio, com o formato geral primeiro.
+func buscarFormatosPartida(ctx context.Context, db consultor, torneioID int) ([]models.FormatoPartida, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, id_torneio, id_categoria, melhor_de, games_por_set, pontos_por_game,
+			sem_vantagem, tiebreak_empate, super_tiebreak_decisivo
+		FROM formatos_partida
+		WHERE id_torneio = $1
+		ORDER BY id_categoria NULLS FIRST`, torneioID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar formatos de partida do torneio %d: %w", torneioID, err)
+	}
+	defer rows.Close()
+
+	formatos := []models.FormatoPartida{}
+	for rows.Next() {
+		var f models.FormatoPartida
+		err := rows.Scan(&f.ID, &f.TorneioID, &f.CategoriaID, &f.MelhorDe, &f.GamesPorSet, &f.PontosPorGame,
+			&f.SemVantagem, &f.TiebreakEmpate, &f.SuperTiebreakDecisivo)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler formato de partida: %w", err)
+		}
+		formatos = append(formatos, f)
+	}
+	return formatos, rows.Err()
+}
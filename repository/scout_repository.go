@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"competitions/models"
+	"competitions/rating"
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ScoutRepository define a interface para as operações de manutenção das estatísticas dos jogadores.
+type ScoutRepository interface {
+	Recalcular(ctx context.Context, simulacao bool) (models.RelatorioRecalculo, error)
+}
+
+// pgScoutRepository é a implementação concreta para ScoutRepository.
+type pgScoutRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewScoutRepository cria uma nova instância de ScoutRepository.
+func NewScoutRepository(db *pgxpool.Pool) ScoutRepository {
+	return &pgScoutRepository{db: db}
+}
+
+// Recalcular reprocessa todos os jogos encerrados em ordem cronológica e recalcula as
+// vitórias, derrotas e títulos (scouts), os ratings por esporte e modalidade e o histórico
+// de ratings de todos os jogadores. Em uma simulação, apenas o relatório de diferenças é
+// gerado; caso contrário, as estatísticas gravadas são substituídas pelas recalculadas.
+// A tabela de jogos fica bloqueada para escrita durante o recálculo.
+func (r *pgScoutRepository) Recalcular(ctx context.Context, simulacao bool) (models.RelatorioRecalculo, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.RelatorioRecalculo{}, fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "LOCK TABLE jogos IN SHARE MODE"); err != nil {
+		return models.RelatorioRecalculo{}, fmt.Errorf("falha ao bloquear a tabela de jogos: %w", err)
+	}
+
+	configuracoes, err := configuracoesRating(ctx, tx)
+	if err != nil {
+		return models.RelatorioRecalculo{}, err
+	}
+	jogos, err := jogosParaRecalculo(ctx, tx)
+	if err != nil {
+		return models.RelatorioRecalculo{}, err
+	}
+	resultado, err := models.RecalcularEstatisticas(jogos, configuracoes)
+	if err != nil {
+		return models.RelatorioRecalculo{}, fmt.Errorf("falha ao recalcular estatísticas: %w", err)
+	}
+
+	scoutsAtuais, err := scoutsAtuais(ctx, tx)
+	if err != nil {
+		return models.RelatorioRecalculo{}, err
+	}
+	ratingsAtuais, err := ratingsAtuais(ctx, tx)
+	if err != nil {
+		return models.RelatorioRecalculo{}, err
+	}
+
+	relatorio := models.RelatorioRecalculo{Simulacao: simulacao, JogosProcessados: len(jogos)}
+	relatorio.Scouts, relatorio.Ratings = models.CompararRecalculo(scoutsAtuais, ratingsAtuais, resultado)
+	if simulacao {
+		return relatorio, nil
+	}
+
+	if err := gravarRecalculo(ctx, tx, relatorio.Scouts, resultado); err != nil {
+		return models.RelatorioRecalculo{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return models.RelatorioRecalculo{}, fmt.Errorf("falha ao commitar transação: %w", err)
+	}
+	return relatorio, nil
+}
+
+// configuracoesRating lê a configuração de rating de todos os esportes.
+func configuracoesRating(ctx context.Context, db consultor) (map[int]rating.Configuracao, error) {
+	rows, err := db.Query(ctx, "SELECT id, algoritmo_rating, fator_k_rating, tau_glicko FROM esportes")
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar configurações de rating: %w", err)
+	}
+	defer rows.Close()
+
+	configuracoes := map[int]rating.Configuracao{}
+	for rows.Next() {
+		var id, fatorK int
+		var cfg rating.Configuracao
+		if err := rows.Scan(&id, &cfg.Algoritmo, &fatorK, &cfg.Tau); err != nil {
+			return nil, fmt.Errorf("falha ao ler configuração de rating: %w", err)
+		}
+		cfg.FatorK = float64(fatorK)
+		configuracoes[id] = cfg
+	}
+	return configuracoes, rows.Err()
+}
+
+// jogosParaRecalculo carrega os jogos encerrados com resultado, em ordem cronológica.
+func jogosParaRecalculo(ctx context.Context, db consultor) ([]models.JogoRecalculo, error) {
+	rows, err := db.Query(ctx, `
+		SELECT jg.id, t.id_esporte, jg.tipo_modalidade, jg.data_hora, COALESCE(jg.eh_final_campeonato, FALSE),
+			jg.id_jogador_vencedor, jg.id_jogador_perdedor, jg.id_dupla_vencedora, jg.id_dupla_perdedora,
+			dv.id_jogador_a, dv.id_jogador_b, dp.id_jogador_a, dp.id_jogador_b
+		FROM jogos jg
+		JOIN torneios t ON t.id = jg.id_torneio
+		LEFT JOIN duplas dv ON dv.id = jg.id_dupla_vencedora
+		LEFT JOIN duplas dp ON dp.id = jg.id_dupla_perdedora
+		WHERE jg.situacao = 'encerrado'
+			AND ((jg.tipo_modalidade = 'simples' AND jg.id_jogador_vencedor IS NOT NULL AND jg.id_jogador_perdedor IS NOT NULL)
+				OR (jg.tipo_modalidade = 'duplas' AND dv.id IS NOT NULL AND dp.id IS NOT NULL))
+		ORDER BY jg.data_hora, jg.id`)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar jogos encerrados: %w", err)
+	}
+	defer rows.Close()
+
+	var jogos []models.JogoRecalculo
+	for rows.Next() {
+		var jg models.JogoRecalculo
+		var va, vb, pa, pb *int
+		err := rows.Scan(&jg.JogoID, &jg.EsporteID, &jg.TipoModalidade, &jg.DataHora, &jg.Final,
+			&jg.JogadorVencedor, &jg.JogadorPerdedor, &jg.DuplaVencedora, &jg.DuplaPerdedora, &va, &vb, &pa, &pb)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler jogo encerrado: %w", err)
+		}
+		if jg.TipoModalidade == "duplas" {
+			jg.Vencedores, jg.Perdedores = []int{*va, *vb}, []int{*pa, *pb}
+		} else {
+			jg.Vencedores, jg.Perdedores = []int{*jg.JogadorVencedor}, []int{*jg.JogadorPerdedor}
+		}
+		jogos = append(jogos, jg)
+	}
+	return jogos, rows.Err()
+}
+
+// scoutsAtuais lê as estatísticas gravadas de todos os jogadores.
+func scoutsAtuais(ctx context.Context, db consultor) (map[int]models.EstatisticasScout, error) {
+	rows, err := db.Query(ctx, `
+		SELECT j.id, s.vitorias, s.derrotas, s.titulos
+		FROM jogadores j JOIN scouts s ON s.id = j.id_scout`)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar scouts: %w", err)
+	}
+	defer rows.Close()
+
+	scouts := map[int]models.EstatisticasScout{}
+	for rows.Next() {
+		var id int
+		var s models.EstatisticasScout
+		if err := rows.Scan(&id, &s.Vitorias, &s.Derrotas, &s.Titulos); err != nil {
+			return nil, fmt.Errorf("falha ao ler scout: %w", err)
+		}
+		scouts[id] = s
+	}
+	return scouts, rows.Err()
+}
+
+// ratingsAtuais lê os ratings gravados de todos os jogadores.
+func ratingsAtuais(ctx context.Context, db consultor) (map[models.ChaveRating]models.EstadoRating, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id_jogador, id_esporte, tipo_modalidade, rating, desvio_rating, volatilidade, jogos, atualizado_em
+		FROM ratings_jogadores`)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar ratings: %w", err)
+	}
+	defer rows.Close()
+
+	ratings := map[models.ChaveRating]models.EstadoRating{}
+	for rows.Next() {
+		var c models.ChaveRating
+		var e models.EstadoRating
+		var r int
+		if err := rows.Scan(&c.JogadorID, &c.EsporteID, &c.TipoModalidade, &r, &e.Desvio, &e.Volatilidade, &e.Jogos, &e.AtualizadoEm); err != nil {
+			return nil, fmt.Errorf("falha ao ler rating: %w", err)
+		}
+		e.Rating = float64(r)
+		ratings[c] = e
+	}
+	return ratings, rows.Err()
+}
+
+// gravarRecalculo corrige os scouts divergentes e substitui os ratings e o histórico pelos recalculados.
+func gravarRecalculo(ctx context.Context, tx pgx.Tx, difScouts []models.DiferencaScout, res models.ResultadoRecalculo) error {
+	for _, d := range difScouts {
+		_, err := tx.Exec(ctx, `
+			UPDATE scouts SET vitorias = $1, derrotas = $2, titulos = $3
+			WHERE id = (SELECT id_scout FROM jogadores WHERE id = $4)`,
+			d.Recalculado.Vitorias, d.Recalculado.Derrotas, d.Recalculado.Titulos, d.JogadorID,
+		)
+		if err != nil {
+			return fmt.Errorf("falha ao corrigir scout do jogador %d: %w", d.JogadorID, err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM ratings_jogadores"); err != nil {
+		return fmt.Errorf("falha ao limpar ratings: %w", err)
+	}
+	for c, e := range res.Ratings {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO ratings_jogadores (id_jogador, id_esporte, tipo_modalidade, rating, desvio_rating, volatilidade, jogos, atualizado_em)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			c.JogadorID, c.EsporteID, c.TipoModalidade, int(math.Round(e.Rating)), e.Desvio, e.Volatilidade, e.Jogos, e.AtualizadoEm,
+		)
+		if err != nil {
+			return fmt.Errorf("falha ao gravar rating do jogador %d: %w", c.JogadorID, err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM historico_ratings"); err != nil {
+		return fmt.Errorf("falha ao limpar histórico de ratings: %w", err)
+	}
+	for _, h := range res.Historico {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO historico_ratings (
+				id_jogador, id_esporte, tipo_modalidade, id_jogo, data_jogo,
+				rating_antes, rating_depois, delta, id_jogador_adversario, id_dupla_adversaria, rating_adversario
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			h.JogadorID, h.EsporteID, h.TipoModalidade, h.JogoID, h.DataJogo,
+			h.RatingAntes, h.RatingDepois, h.Delta, h.JogadorAdversarioID, h.DuplaAdversariaID, h.RatingAdversario,
+		)
+		if err != nil {
+			return fmt.Errorf("falha ao gravar histórico de rating do jogador %d: %w", h.JogadorID, err)
+		}
+	}
+	return nil
+}
@@ -4,19 +4,28 @@ import (
 	"competitions/models"
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type TorneioRepository interface {
-	Create(ctx context.Context, input models.TorneioInput) (models.Torneio, error)
+	Create(ctx context.Context, input models.TorneioInput, criadorID int) (models.Torneio, error)
 	FindAll(ctx context.Context) ([]models.Torneio, error)
 	FindByID(ctx context.Context, id int) (models.Torneio, error)
 	Update(ctx context.Context, id int, input models.TorneioInput) (int64, error)
 	Delete(ctx context.Context, id int) (int64, error)
 	InscreverJogador(ctx context.Context, inscricao models.JogadorTorneio) (models.JogadorTorneio, error)
 	ListarInscricoesPorTorneio(ctx context.Context, torneioID int) ([]models.InscricaoDetalhada, error)
+	FindFormatosPartida(ctx context.Context, torneioID int) ([]models.FormatoPartida, error)
+	SalvarFormatoPartida(ctx context.Context, torneioID int, input models.FormatoPartidaInput) (models.FormatoPartida, error)
+	DeleteFormatoPartida(ctx context.Context, torneioID int, categoriaID *int) (int64, error)
+	FindOrganizadores(ctx context.Context, torneioID int) ([]models.OrganizadorTorneio, error)
+	AdicionarOrganizador(ctx context.Context, torneioID, usuarioID int) error
+	RemoverOrganizador(ctx context.Context, torneioID, usuarioID int) (int64, error)
+	EhOrganizador(ctx context.Context, torneioID, usuarioID int) (bool, error)
+	EhCriador(ctx context.Context, torneioID, usuarioID int) (bool, error)
 }
 
 // pgTorneioRepository é a implementação concreta para TorneioRepository.
@@ -29,18 +38,20 @@ func NewTorneioRepository(db *pgxpool.Pool) TorneioRepository {
 	return &pgTorneioRepository{db: db}
 }
 
-// Create insere um novo torneio no banco de dados.
-func (r *pgTorneioRepository) Create(ctx context.Context, input models.TorneioInput) (models.Torneio, error) {
+// Create insere um novo torneio no banco de dados, registrando o usuário que o criou como dono.
+func (r *pgTorneioRepository) Create(ctx context.Context, input models.TorneioInput, criadorID int) (models.Torneio, error) {
 	var torneio models.Torneio
 	query := `
-        INSERT INTO torneios (nome, data_inicio, data_fim, id_esporte, id_cidade, id_estado, id_pais)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
-        RETURNING id, nome, data_inicio, data_fim, id_esporte, id_cidade, id_estado, id_pais, criado_em`
+        INSERT INTO torneios (nome, inicio, fim, id_esporte, id_cidade, id_estado, id_pais, quantidade_quadras, criterios_desempate, id_usuario_criador)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING id, nome, inicio, fim, id_esporte, id_cidade, id_estado, id_pais, criado_em, quantidade_quadras, criterios_desempate, id_usuario_criador`
 	err := r.db.QueryRow(ctx, query,
 		input.Nome, input.DataInicio, input.DataFim, input.EsporteID, input.CidadeID, input.EstadoID, input.PaisID,
+		quantidadeQuadras(input), criteriosDesempate(input), criadorID,
 	).Scan(
 		&torneio.ID, &torneio.Nome, &torneio.DataInicio, &torneio.DataFim,
 		&torneio.EsporteID, &torneio.CidadeID, &torneio.EstadoID, &torneio.PaisID, &torneio.CriadoEm,
+		&torneio.QuantidadeQuadras, &torneio.CriteriosDesempate, &torneio.CriadorID,
 	)
 	return torneio, err
 }
@@ -48,9 +59,10 @@ func (r *pgTorneioRepository) Create(ctx context.Context, input models.TorneioIn
 // FindAll recupera todos os torneios do banco de dados.
 func (r *pgTorneioRepository) FindAll(ctx context.Context) ([]models.Torneio, error) {
 	query := `
-        SELECT id, nome, data_inicio, data_fim, id_esporte, id_cidade, id_estado, id_pais, criado_em
+        SELECT id, nome, inicio, fim, id_esporte, id_cidade, id_estado, id_pais, criado_em, quantidade_quadras, criterios_desempate,
+            id_usuario_criador
         FROM torneios
-        ORDER BY data_inicio DESC`
+        ORDER BY inicio DESC`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -63,7 +75,8 @@ func (r *pgTorneioRepository) FindAll(ctx context.Context) ([]models.Torneio, er
 // FindByID recupera um único torneio pelo seu ID.
 func (r *pgTorneioRepository) FindByID(ctx context.Context, id int) (models.Torneio, error) {
 	query := `
-        SELECT id, nome, data_inicio, data_fim, id_esporte, id_cidade, id_estado, id_pais, criado_em
+        SELECT id, nome, inicio, fim, id_esporte, id_cidade, id_estado, id_pais, criado_em, quantidade_quadras, criterios_desempate,
+            id_usuario_criador
         FROM torneios
         WHERE id = $1`
 	rows, err := r.db.Query(ctx, query, id)
@@ -78,10 +91,12 @@ func (r *pgTorneioRepository) FindByID(ctx context.Context, id int) (models.Torn
 func (r *pgTorneioRepository) Update(ctx context.Context, id int, input models.TorneioInput) (int64, error) {
 	query := `
         UPDATE torneios
-        SET nome = $1, data_inicio = $2, data_fim = $3, id_esporte = $4, id_cidade = $5, id_estado = $6, id_pais = $7
-        WHERE id = $8`
+        SET nome = $1, inicio = $2, fim = $3, id_esporte = $4, id_cidade = $5, id_estado = $6, id_pais = $7,
+            quantidade_quadras = $8, criterios_desempate = $9
+        WHERE id = $10`
 	result, err := r.db.Exec(ctx, query,
-		input.Nome, input.DataInicio, input.DataFim, input.EsporteID, input.CidadeID, input.EstadoID, input.PaisID, id,
+		input.Nome, input.DataInicio, input.DataFim, input.EsporteID, input.CidadeID, input.EstadoID, input.PaisID,
+		quantidadeQuadras(input), criteriosDesempate(input), id,
 	)
 	if err != nil {
 		return 0, err
@@ -89,6 +104,22 @@ func (r *pgTorneioRepository) Update(ctx context.Context, id int, input models.T
 	return result.RowsAffected(), nil
 }
 
+// quantidadeQuadras retorna a quantidade de quadras informada ou, se omitida, uma única quadra.
+func quantidadeQuadras(input models.TorneioInput) int {
+	if input.QuantidadeQuadras == 0 {
+		return 1
+	}
+	return input.QuantidadeQuadras
+}
+
+// criteriosDesempate retorna os critérios de desempate informados ou, se omitidos, os critérios padrão.
+func criteriosDesempate(input models.TorneioInput) []string {
+	if len(input.CriteriosDesempate) == 0 {
+		return models.CriteriosDesempatePadrao
+	}
+	return input.CriteriosDesempate
+}
+
 // Delete remove um torneio do banco de dados.
 func (r *pgTorneioRepository) Delete(ctx context.Context, id int) (int64, error) {
 	query := "DELETE FROM torneios WHERE id = $1"
@@ -185,3 +216,129 @@ func (r *pgTorneioRepository) ListarInscricoesPorTorneio(ctx context.Context, to
 
 	return inscricoes, nil
 }
+
+// FindFormatosPartida recupera os formatos de partida de um torneio, com a duração
+// estimada de uma partida em cada formato.
+func (r *pgTorneioRepository) FindFormatosPartida(ctx context.Context, torneioID int) ([]models.FormatoPartida, error) {
+	rt, err := carregarRegrasTorneio(ctx, r.db, torneioID)
+	if err != nil {
+		return nil, err
+	}
+	formatos, err := buscarFormatosPartida(ctx, r.db, torneioID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range formatos {
+		formatos[i].DuracaoEstimadaMinutos = int(rt.duracaoCategoria(formatos[i].CategoriaID).Minutes())
+	}
+	return formatos, nil
+}
+
+// SalvarFormatoPartida cria ou substitui o formato de partida geral do torneio (sem
+// categoria) ou o de uma de suas categorias.
+func (r *pgTorneioRepository) SalvarFormatoPartida(ctx context.Context, torneioID int, input models.FormatoPartidaInput) (models.FormatoPartida, error) {
+	conflito := "(id_torneio) WHERE id_categoria IS NULL"
+	if input.CategoriaID != nil {
+		conflito = "(id_torneio, id_categoria) WHERE id_categoria IS NOT NULL"
+	}
+	query := `
+		INSERT INTO formatos_partida (id_torneio, id_categoria, melhor_de, games_por_set, pontos_por_game,
+			sem_vantagem, tiebreak_empate, super_tiebreak_decisivo)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT ` + conflito + ` DO UPDATE SET
+			melhor_de = EXCLUDED.melhor_de, games_por_set = EXCLUDED.games_por_set,
+			pontos_por_game = EXCLUDED.pontos_por_game, sem_vantagem = EXCLUDED.sem_vantagem,
+			tiebreak_empate = EXCLUDED.tiebreak_empate, super_tiebreak_decisivo = EXCLUDED.super_tiebreak_decisivo
+		RETURNING id`
+	var id int
+	err := r.db.QueryRow(ctx, query,
+		torneioID, input.CategoriaID, input.MelhorDe, input.GamesPorSet, input.PontosPorGame,
+		input.SemVantagem, input.TiebreakEmpate, input.SuperTiebreakDecisivo,
+	).Scan(&id)
+	if err != nil {
+		return models.FormatoPartida{}, err
+	}
+
+	formatos, err := r.FindFormatosPartida(ctx, torneioID)
+	if err != nil {
+		return models.FormatoPartida{}, err
+	}
+	for _, f := range formatos {
+		if f.ID == id {
+			return f, nil
+		}
+	}
+	return models.FormatoPartida{}, pgx.ErrNoRows
+}
+
+// DeleteFormatoPartida remove o formato de partida geral do torneio (categoriaID nulo) ou
+// o de uma categoria; as partidas voltam a seguir o formato geral ou a regra do esporte.
+func (r *pgTorneioRepository) DeleteFormatoPartida(ctx context.Context, torneioID int, categoriaID *int) (int64, error) {
+	result, err := r.db.Exec(ctx,
+		"DELETE FROM formatos_partida WHERE id_torneio = $1 AND id_categoria IS NOT DISTINCT FROM $2",
+		torneioID, categoriaID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// FindOrganizadores lista os organizadores de um torneio: o criador, seguido dos coorganizadores.
+func (r *pgTorneioRepository) FindOrganizadores(ctx context.Context, torneioID int) ([]models.OrganizadorTorneio, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT u.id, u.nome, u.username, TRUE
+		FROM torneios t JOIN usuarios u ON u.id = t.id_usuario_criador
+		WHERE t.id = $1
+		UNION ALL
+		SELECT u.id, u.nome, u.username, FALSE
+		FROM torneios_organizadores o
+		JOIN torneios t ON t.id = o.id_torneio
+		JOIN usuarios u ON u.id = o.id_usuario
+		WHERE o.id_torneio = $1 AND o.id_usuario IS DISTINCT FROM t.id_usuario_criador
+		ORDER BY 4 DESC, 2`, torneioID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizadores := []models.OrganizadorTorneio{}
+	for rows.Next() {
+		var o models.OrganizadorTorneio
+		if err := rows.Scan(&o.UsuarioID, &o.Nome, &o.Username, &o.Criador); err != nil {
+			return nil, fmt.Errorf("falha ao ler organizador do torneio: %w", err)
+		}
+		organizadores = append(organizadores, o)
+	}
+	return organizadores, rows.Err()
+}
+
+// AdicionarOrganizador adiciona um coorganizador ao torneio. Adicionar um usuário que já é
+// coorganizador não tem efeito.
+func (r *pgTorneioRepository) AdicionarOrganizador(ctx context.Context, torneioID, usuarioID int) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO torneios_organizadores (id_torneio, id_usuario) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, torneioID, usuarioID)
+	return err
+}
+
+// RemoverOrganizador remove um coorganizador do torneio. O criador não pode ser removido.
+func (r *pgTorneioRepository) RemoverOrganizador(ctx context.Context, torneioID, usuarioID int) (int64, error) {
+	result, err := r.db.Exec(ctx,
+		"DELETE FROM torneios_organizadores WHERE id_torneio = $1 AND id_usuario = $2", torneioID, usuarioID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// EhOrganizador informa se o usuário criou ou é coorganizador do torneio. Retorna
+// pgx.ErrNoRows se o torneio não existir.
+func (r *pgTorneioRepository) EhOrganizador(ctx context.Context, torneioID, usuarioID int) (bool, error) {
+	return organizaTorneio(ctx, r.db, torneioPorID, torneioID, usuarioID, false)
+}
+
+// EhCriador informa se o usuário criou o torneio. Retorna pgx.ErrNoRows se o torneio não existir.
+func (r *pgTorneioRepository) EhCriador(ctx context.Context, torneioID, usuarioID int) (bool, error) {
+	return organizaTorneio(ctx, r.db, torneioPorID, torneioID, usuarioID, true)
+}
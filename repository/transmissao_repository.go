@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"competitions/models"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TransmissaoRepository define a interface para a leitura dos eventos da transmissão ao vivo dos torneios.
+type TransmissaoRepository interface {
+	PosicaoAtual(ctx context.Context, torneioID int) (models.PosicaoTransmissao, error)
+	FindEventos(ctx context.Context, filtro models.FiltroTransmissao) ([]models.EventoTransmissao, error)
+}
+
+// pgTransmissaoRepository é a implementação concreta para TransmissaoRepository.
+type pgTransmissaoRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTransmissaoRepository cria uma nova instância de TransmissaoRepository.
+func NewTransmissaoRepository(db *pgxpool.Pool) TransmissaoRepository {
+	return &pgTransmissaoRepository{db: db}
+}
+
+// limiteEventosTransmissao é a quantidade máxima de eventos lidos por consulta quando o filtro não define outra.
+const limiteEventosTransmissao = 500
+
+// PosicaoAtual retorna a posição a partir da qual um novo espectador do torneio passa a
+// receber eventos: todos os eventos de transações ainda em andamento ou futuras. Retorna
+// pgx.ErrNoRows se o torneio não existir.
+func (r *pgTransmissaoRepository) PosicaoAtual(ctx context.Context, torneioID int) (models.PosicaoTransmissao, error) {
+	var xmin int64
+	err := r.db.QueryRow(ctx, `
+		SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+		FROM torneios WHERE id = $1`, torneioID,
+	).Scan(&xmin)
+	if err != nil {
+		return models.PosicaoTransmissao{}, err
+	}
+	// Posição imediatamente anterior ao primeiro evento da transação xmin.
+	return models.PosicaoTransmissao{Transacao: xmin - 1, Evento: 1<<63 - 1}, nil
+}
+
+// FindEventos lê os eventos do torneio posteriores à posição do filtro, na ordem da
+// transmissão. Eventos de transações ainda em andamento, ou que podem ter gravado eventos
+// antes delas, ficam para a próxima leitura, para que nenhum evento seja pulado.
+func (r *pgTransmissaoRepository) FindEventos(ctx context.Context, filtro models.FiltroTransmissao) ([]models.EventoTransmissao, error) {
+	args := []any{filtro.TorneioID, strconv.FormatInt(filtro.Apos.Transacao, 10), filtro.Apos.Evento}
+	condicoes := []string{
+		"id_torneio = $1",
+		"(transacao, id) > ($2::text::xid8, $3)",
+		"transacao < pg_snapshot_xmin(pg_current_snapshot())",
+	}
+	if filtro.JogoID > 0 {
+		args = append(args, filtro.JogoID)
+		condicoes = append(condicoes, fmt.Sprintf("id_jogo = $%d", len(args)))
+	}
+	if filtro.Quadra != "" {
+		args = append(args, filtro.Quadra)
+		condicoes = append(condicoes, fmt.Sprintf("(quadra = $%[1]d OR quadra_anterior = $%[1]d)", len(args)))
+	}
+	limite := filtro.Limite
+	if limite <= 0 {
+		limite = limiteEventosTransmissao
+	}
+	args = append(args, limite)
+
+	query := fmt.Sprintf(`
+		SELECT transacao::text::bigint, id, tipo, id_torneio, id_jogo, quadra, quadra_anterior, dados, criado_em
+		FROM eventos_transmissao
+		WHERE %s
+		ORDER BY transacao, id
+		LIMIT $%d`, strings.Join(condicoes, " AND "), len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar eventos da transmissão do torneio %d: %w", filtro.TorneioID, err)
+	}
+	defer rows.Close()
+
+	eventos := []models.EventoTransmissao{}
+	for rows.Next() {
+		var e models.EventoTransmissao
+		err := rows.Scan(&e.Posicao.Transacao, &e.Posicao.Evento, &e.Tipo, &e.TorneioID, &e.JogoID,
+			&e.Quadra, &e.QuadraAnterior, &e.Dados, &e.CriadoEm)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler evento da transmissão: %w", err)
+		}
+		e.ID = e.Posicao.String()
+		eventos = append(eventos, e)
+	}
+	return eventos, rows.Err()
+}
+
+// transmitirJogo grava um evento na transmissão ao vivo do torneio de um jogo, dentro da
+// transação fornecida. A quadra é a atual do jogo.
+func transmitirJogo(ctx context.Context, tx pgx.Tx, jogoID int, tipo string, dados any) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO eventos_transmissao (id_torneio, id_jogo, tipo, quadra, dados)
+		SELECT id_torneio, id, $2, localizacao, $3 FROM jogos WHERE id = $1`,
+		jogoID, tipo, dados,
+	)
+	if err != nil {
+		return fmt.Errorf("falha ao transmitir evento '%s' do jogo %d: %w", tipo, jogoID, err)
+	}
+	return nil
+}
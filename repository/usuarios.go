@@ -50,12 +50,18 @@ type UsuarioRepository interface {
 	FindByIDForAuth(ctx context.Context, id uint) (*models.Usuario, error)
 	FindByEmail(ctx context.Context, email string) (*models.Usuario, error)
 	Create(ctx context.Context, usuario *models.Usuario) error // This method already accepts *models.Usuario
+	CreateJogador(ctx context.Context, usuario *models.Usuario, sexo, tipoMao string) error
 	Update(ctx context.Context, usuario *models.Usuario) (int64, error)
 	UpdatePassword(ctx context.Context, id uint, newPassword string) (int64, error)
+	FindVersaoToken(ctx context.Context, id uint) (versao int, ativo bool, err error)
+	RevogarTokens(ctx context.Context, id uint) (int64, error)
 	Delete(ctx context.Context, id int) (int64, error)
 	AssociateEsporte(ctx context.Context, usuarioID int, esporteIDs []int) error
 	GetEsportesByUsuario(ctx context.Context, userID int) ([]models.Esporte, error)
 	GetUsuariosByEsporte(ctx context.Context, esporteID int) ([]models.Usuario, error)
+	GetRatingsByUsuario(ctx context.Context, userID int) ([]models.RatingJogador, error)
+	GetHistoricoRatingsByUsuario(ctx context.Context, userID int, filtro models.FiltroHistoricoRating) ([]models.HistoricoRating, error)
+	GetInscricoesByUsuario(ctx context.Context, userID int) ([]models.InscricaoUsuario, error)
 }
 
 // postgresUsuarioRepository é a implementação concreta do repositório de usuários.
@@ -171,6 +177,149 @@ func (r *postgresUsuarioRepository) GetEsportesByUsuario(ctx context.Context, us
 	return esportes, nil
 }
 
+// jogadorDoUsuario retorna o ID do jogador de um usuário, ou ErrJogadorNaoEncontrado.
+func (r *postgresUsuarioRepository) jogadorDoUsuario(ctx context.Context, userID int) (int, error) {
+	var jogadorID int
+	err := r.db.QueryRow(ctx, "SELECT id FROM jogadores WHERE id_usuario = $1", userID).Scan(&jogadorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrJogadorNaoEncontrado
+		}
+		return 0, fmt.Errorf("erro ao buscar jogador do usuário %d: %w", userID, err)
+	}
+	return jogadorID, nil
+}
+
+// GetInscricoesByUsuario retorna as inscrições do jogador de um usuário em torneios, tanto
+// individuais quanto com as duplas de que ele faz parte, dos torneios mais recentes aos mais
+// antigos. Retorna ErrJogadorNaoEncontrado se o usuário não for um jogador.
+func (r *postgresUsuarioRepository) GetInscricoesByUsuario(ctx context.Context, userID int) ([]models.InscricaoUsuario, error) {
+	jogadorID, err := r.jogadorDoUsuario(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT jt.id, t.id, t.nome, t.inicio, t.fim, jt.id_categoria, jt.tipo_modalidade,
+		       d.id, d.nome_dupla, ja.id, ja.nome, jb.id, jb.nome
+		FROM jogadores_torneios jt
+		JOIN torneios t ON t.id = jt.id_torneio
+		LEFT JOIN duplas d ON d.id = jt.id_dupla
+		LEFT JOIN jogadores ja ON ja.id = d.id_jogador_a
+		LEFT JOIN jogadores jb ON jb.id = d.id_jogador_b
+		WHERE jt.id_jogador = $1 OR $1 IN (d.id_jogador_a, d.id_jogador_b)
+		ORDER BY t.inicio DESC, jt.id`, jogadorID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar inscrições do usuário %d: %w", userID, err)
+	}
+	defer rows.Close()
+
+	inscricoes := []models.InscricaoUsuario{}
+	for rows.Next() {
+		var i models.InscricaoUsuario
+		var duplaID, jogadorAID, jogadorBID *int
+		var nomeDupla, jogadorANome, jogadorBNome *string
+		err := rows.Scan(&i.InscricaoID, &i.TorneioID, &i.TorneioNome, &i.DataInicio, &i.DataFim,
+			&i.CategoriaID, &i.TipoModalidade, &duplaID, &nomeDupla, &jogadorAID, &jogadorANome, &jogadorBID, &jogadorBNome)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler inscrição do usuário: %w", err)
+		}
+		if duplaID != nil {
+			i.Dupla = &models.DuplaDetalhes{
+				ID:        *duplaID,
+				NomeDupla: nomeDupla,
+				JogadorA:  &models.JogadorDetalhes{ID: *jogadorAID, Nome: *jogadorANome},
+				JogadorB:  &models.JogadorDetalhes{ID: *jogadorBID, Nome: *jogadorBNome},
+			}
+		}
+		inscricoes = append(inscricoes, i)
+	}
+	return inscricoes, rows.Err()
+}
+
+// GetRatingsByUsuario retorna os ratings do jogador de um usuário em cada esporte e modalidade
+// em que já disputou jogos. Retorna ErrJogadorNaoEncontrado se o usuário não for um jogador.
+func (r *postgresUsuarioRepository) GetRatingsByUsuario(ctx context.Context, userID int) ([]models.RatingJogador, error) {
+	jogadorID, err := r.jogadorDoUsuario(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT r.id_jogador, r.id_esporte, e.nome, r.tipo_modalidade, r.rating, r.desvio_rating, r.jogos, r.atualizado_em
+		FROM ratings_jogadores r
+		JOIN esportes e ON e.id = r.id_esporte
+		WHERE r.id_jogador = $1
+		ORDER BY e.nome, r.tipo_modalidade
+	`
+	rows, err := r.db.Query(ctx, query, jogadorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ratings := []models.RatingJogador{}
+	for rows.Next() {
+		var rt models.RatingJogador
+		if err := rows.Scan(&rt.JogadorID, &rt.EsporteID, &rt.Esporte, &rt.TipoModalidade, &rt.Rating, &rt.Desvio, &rt.Jogos, &rt.AtualizadoEm); err != nil {
+			return nil, fmt.Errorf("erro ao ler rating do jogador: %w", err)
+		}
+		ratings = append(ratings, rt)
+	}
+	return ratings, rows.Err()
+}
+
+// GetHistoricoRatingsByUsuario retorna a linha do tempo de ratings do jogador de um usuário,
+// em ordem cronológica dos jogos, aplicando os filtros informados.
+func (r *postgresUsuarioRepository) GetHistoricoRatingsByUsuario(ctx context.Context, userID int, filtro models.FiltroHistoricoRating) ([]models.HistoricoRating, error) {
+	jogadorID, err := r.jogadorDoUsuario(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, id_jogador, id_esporte, tipo_modalidade, id_jogo, data_jogo, rating_antes, rating_depois,
+			delta, id_jogador_adversario, id_dupla_adversaria, rating_adversario
+		FROM historico_ratings
+		WHERE id_jogador = $1`
+	args := []any{jogadorID}
+	if filtro.EsporteID > 0 {
+		args = append(args, filtro.EsporteID)
+		query += fmt.Sprintf(" AND id_esporte = $%d", len(args))
+	}
+	if filtro.TipoModalidade != "" {
+		args = append(args, filtro.TipoModalidade)
+		query += fmt.Sprintf(" AND tipo_modalidade = $%d", len(args))
+	}
+	if filtro.De != nil {
+		args = append(args, *filtro.De)
+		query += fmt.Sprintf(" AND data_jogo >= $%d", len(args))
+	}
+	if filtro.Ate != nil {
+		args = append(args, *filtro.Ate)
+		query += fmt.Sprintf(" AND data_jogo < $%d", len(args))
+	}
+	query += " ORDER BY data_jogo, id"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	historico := []models.HistoricoRating{}
+	for rows.Next() {
+		var h models.HistoricoRating
+		err := rows.Scan(&h.ID, &h.JogadorID, &h.EsporteID, &h.TipoModalidade, &h.JogoID, &h.DataJogo, &h.RatingAntes,
+			&h.RatingDepois, &h.Delta, &h.JogadorAdversarioID, &h.DuplaAdversariaID, &h.RatingAdversario)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler histórico de rating: %w", err)
+		}
+		historico = append(historico, h)
+	}
+	return historico, rows.Err()
+}
+
 // Create insere um novo usuário no banco de dados.
 // Este método realiza uma inserção na tabela 'usuarios' e retorna o ID do usuário recém
 // criado, juntamente com a data de criação. A senha deve ser criptografada antes de
@@ -202,6 +351,35 @@ func (r *postgresUsuarioRepository) Create(ctx context.Context, usuario *models.
 	).Scan(&usuario.ID, &usuario.CriadoEm)
 }
 
+// CreateJogador insere um usuário do tipo 'jogador' e completa o jogador criado pela trigger
+// inserir_jogador_ao_criar_usuario com o sexo e o tipo de mão informados no cadastro, no
+// lugar dos valores padrão da trigger.
+func (r *postgresUsuarioRepository) CreateJogador(ctx context.Context, usuario *models.Usuario, sexo, tipoMao string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("falha ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	usuario.Tipo = models.TipoJogador
+	err = tx.QueryRow(ctx, `
+        INSERT INTO usuarios (tipo, nome, username, cpf, data_nascimento, email, password, telefone, instagram, ativo)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING id, criado_em`,
+		usuario.Tipo, usuario.Nome, usuario.Username, usuario.CPF, usuario.DataNascimento,
+		usuario.Email, usuario.Password, usuario.Telefone, usuario.Instagram, usuario.Ativo,
+	).Scan(&usuario.ID, &usuario.CriadoEm)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE jogadores SET sexo = $2, tipo = $3 WHERE id_usuario = $1", usuario.ID, sexo, tipoMao)
+	if err != nil {
+		return fmt.Errorf("falha ao completar o jogador do usuário %d: %w", usuario.ID, err)
+	}
+	return tx.Commit(ctx)
+}
+
 // FindAll recupera todos os usuários do banco de dados.
 // Este método executa uma consulta SQL que seleciona todos os campos da tabela 'usuarios'
 // e retorna uma lista de modelos.Usuario. Ele utiliza o contexto fornecido para permitir
@@ -303,9 +481,11 @@ func (r *postgresUsuarioRepository) FindByIDForAuth(ctx context.Context, id uint
 // A função é útil para atualizar as informações de um usuário existente, permitindo que os usuários
 // modifiquem seus dados pessoais, como nome, e-mail, telefone e outras informações relevantes.
 func (r *postgresUsuarioRepository) Update(ctx context.Context, usuario *models.Usuario) (int64, error) {
-	// A trigger no banco de dados irá sincronizar com a tabela 'jogadores'.
+	// A trigger no banco de dados irá sincronizar com a tabela 'jogadores'. A mudança de tipo
+	// ou a desativação revoga os tokens já emitidos, que carregam o tipo antigo.
 	res, err := r.db.Exec(ctx, `
-        UPDATE usuarios SET tipo=$1, nome=$2, username=$3, cpf=$4, data_nascimento=$5, email=$6, telefone=$7, instagram=$8, ativo=$9
+        UPDATE usuarios SET tipo=$1, nome=$2, username=$3, cpf=$4, data_nascimento=$5, email=$6, telefone=$7, instagram=$8, ativo=$9,
+            versao_token = versao_token + CASE WHEN tipo IS DISTINCT FROM $1 OR (ativo AND NOT $9) THEN 1 ELSE 0 END
         WHERE id=$10`,
 		usuario.Tipo, usuario.Nome, usuario.Username, usuario.CPF, usuario.DataNascimento, usuario.Email, usuario.Telefone, usuario.Instagram, usuario.Ativo, usuario.ID)
 	if err != nil {
@@ -321,7 +501,25 @@ func (r *postgresUsuarioRepository) Update(ctx context.Context, usuario *models.
 // onde o ID do usuário corresponde ao fornecido. A senha deve ser previamente criptografada
 // antes de ser passada para esta função, garantindo que as senhas sejam armazenadas de forma
 func (r *postgresUsuarioRepository) UpdatePassword(ctx context.Context, id uint, newPassword string) (int64, error) {
-	res, err := r.db.Exec(ctx, "UPDATE usuarios SET password = $1 WHERE id = $2", newPassword, id)
+	// A troca de senha revoga os tokens já emitidos.
+	res, err := r.db.Exec(ctx, "UPDATE usuarios SET password = $1, versao_token = versao_token + 1 WHERE id = $2", newPassword, id)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+// FindVersaoToken retorna a versão atual dos tokens do usuário e se a conta está ativa.
+// Retorna pgx.ErrNoRows se o usuário não existir.
+func (r *postgresUsuarioRepository) FindVersaoToken(ctx context.Context, id uint) (versao int, ativo bool, err error) {
+	err = r.db.QueryRow(ctx, "SELECT versao_token, ativo FROM usuarios WHERE id = $1", id).Scan(&versao, &ativo)
+	return versao, ativo, err
+}
+
+// RevogarTokens incrementa a versão dos tokens do usuário, invalidando todos os tokens já
+// emitidos para ele.
+func (r *postgresUsuarioRepository) RevogarTokens(ctx context.Context, id uint) (int64, error) {
+	res, err := r.db.Exec(ctx, "UPDATE usuarios SET versao_token = versao_token + 1 WHERE id = $1", id)
 	if err != nil {
 		return 0, err
 	}
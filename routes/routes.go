@@ -17,6 +17,15 @@ func RegisterRoutes(
 	torneioHandler *handlers.TorneioHandler,
 	esporteHandler *handlers.EsporteHandler,
 	grupoHandler *handlers.GrupoHandler, // Adicionado
+	jogoHandler *handlers.JogoHandler,
+	chaveamentoHandler *handlers.ChaveamentoHandler,
+	duplaHandler *handlers.DuplaHandler,
+	scoutHandler *handlers.ScoutHandler,
+	rankingHandler *handlers.RankingHandler,
+	circuitoHandler *handlers.CircuitoHandler,
+	clubeHandler *handlers.ClubeHandler,
+	transmissaoHandler *handlers.TransmissaoHandler,
+	perfilHandler *handlers.PerfilHandler,
 	authHandler *handlers.AuthHandler,
 	jwtSecret string,
 ) {
@@ -27,50 +36,183 @@ func RegisterRoutes(
 	// Passa authHandler para o middleware para que ele possa usar authHandler.Login como Authenticator
 	authMiddleware := middleware.AuthMiddleware(jwtSecret, authHandler)
 
+	// Permissões por ação, conforme o tipo do usuário autenticado (ver middleware/permissoes.go).
+	// Todo grupo autenticado exige 'consultar'; as rotas de escrita exigem a ação correspondente.
+	consultar := middleware.RequerPermissao(middleware.Consultar)
+	inscrever := middleware.RequerPermissao(middleware.Inscrever)
+	gerenciarTorneios := middleware.RequerPermissao(middleware.GerenciarTorneios)
+	gerenciarUsuarios := middleware.RequerPermissao(middleware.GerenciarUsuarios)
+	configurarEsportes := middleware.RequerPermissao(middleware.ConfigurarEsportes)
+	gerenciarClubes := middleware.RequerPermissao(middleware.GerenciarClubes)
+
+	// Escritas sobre um torneio e seus recursos exigem, além da ação, que o usuário organize o
+	// torneio (criador ou coorganizador); administradores podem alterar qualquer torneio.
+	organizaTorneio := torneioHandler.SomenteOrganizadores(middleware.GerenciarTorneios)
+	criadorTorneio := torneioHandler.SomenteCriador(middleware.GerenciarTorneios)
+	organizaGrupo := grupoHandler.SomenteOrganizadores(middleware.GerenciarTorneios)
+	organizaJogo := jogoHandler.SomenteOrganizadores(middleware.GerenciarTorneios)
+	resultadoJogo := jogoHandler.SomenteOrganizadores(middleware.RegistrarResultados)
+	// O mesmo vale para os clubes, com a ação 'gerenciar_clubes'.
+	organizaClube := clubeHandler.SomenteOrganizadores(middleware.GerenciarClubes)
+	criadorClube := clubeHandler.SomenteCriador(middleware.GerenciarClubes)
+	// Circuitos só podem ser alterados pelo seu criador (e por administradores).
+	criadorCircuito := circuitoHandler.SomenteCriador(middleware.GerenciarTorneios)
+	// Duplas só podem ser alteradas pelos seus jogadores (e por administradores).
+	integraDupla := duplaHandler.SomenteIntegrantes(middleware.Inscrever)
+
 	// Rotas de Autenticação (públicas)
 	authRoutes := router.Group("/auth")
 	{
 		authRoutes.POST("/login", authMiddleware.LoginHandler) // Use o LoginHandler fornecido pelo middleware JWT
+		authRoutes.POST("/signup", authHandler.Signup)
+		authRoutes.POST("/refresh", middleware.RequerTokenRenovavel(authMiddleware, authHandler), authMiddleware.RefreshHandler)
+		authRoutes.POST("/logout", authMiddleware.MiddlewareFunc(), authHandler.Logout)
+	}
+
+	// Rotas do usuário autenticado: o usuário é sempre o do token (claim user_id)
+	meRoutes := router.Group("/me")
+	meRoutes.Use(authMiddleware.MiddlewareFunc(), consultar)
+	{
+		meRoutes.GET("", perfilHandler.GetPerfil)
+		meRoutes.PUT("", perfilHandler.UpdatePerfil)
+		meRoutes.PUT("/change-password", perfilHandler.ChangePassword)
+		meRoutes.GET("/esportes", perfilHandler.GetEsportes)
+		meRoutes.POST("/esportes", perfilHandler.AssociateEsporte)
+		meRoutes.GET("/inscricoes", perfilHandler.GetInscricoes)
+		meRoutes.GET("/jogos", perfilHandler.GetJogos)
 	}
 
-	// Rotas de Usuários
+	// Rotas de Usuários (administrativas, exceto os ratings; cada usuário usa as rotas /me)
 	userRoutes := router.Group("/usuarios")
-	userRoutes.Use(authMiddleware.MiddlewareFunc()) // Proteger rotas de usuário
+	userRoutes.Use(authMiddleware.MiddlewareFunc(), consultar) // Proteger rotas de usuário
 	{
-		userRoutes.POST("", userHandler.CreateUsuario)
-		userRoutes.GET("", userHandler.GetUsuarios)
-		userRoutes.GET("/:id", userHandler.GetUsuarioByID)
-		userRoutes.PUT("/:id", userHandler.UpdateUsuario)
-		userRoutes.DELETE("/:id", userHandler.DeleteUsuario)
-		userRoutes.PUT("/:id/change-password", userHandler.ChangePassword) // Ativar rota de mudança de senha
-		userRoutes.POST("/:id/associar-esporte", userHandler.AssociateEsporte)
+		userRoutes.POST("", gerenciarUsuarios, userHandler.CreateUsuario)
+		userRoutes.GET("", gerenciarUsuarios, userHandler.GetUsuarios)
+		userRoutes.GET("/:id", gerenciarUsuarios, userHandler.GetUsuarioByID)
+		userRoutes.PUT("/:id", gerenciarUsuarios, userHandler.UpdateUsuario)
+		userRoutes.DELETE("/:id", gerenciarUsuarios, userHandler.DeleteUsuario)
+		userRoutes.PUT("/:id/change-password", gerenciarUsuarios, userHandler.ChangePassword) // Ativar rota de mudança de senha
+		userRoutes.POST("/:id/associar-esporte", gerenciarUsuarios, userHandler.AssociateEsporte)
+		userRoutes.GET("/:id/ratings", userHandler.GetRatingsByUsuario)
+		userRoutes.GET("/:id/ratings/historico", userHandler.GetHistoricoRatingsByUsuario)
 	}
 
 	// Rotas de Torneios
 	torneioRoutes := router.Group("/torneios")
-	torneioRoutes.Use(authMiddleware.MiddlewareFunc()) // Proteger rotas de torneio
+	torneioRoutes.Use(authMiddleware.MiddlewareFunc(), consultar) // Proteger rotas de torneio
 	{
-		torneioRoutes.POST("", torneioHandler.CreateTorneio)
+		torneioRoutes.POST("", gerenciarTorneios, torneioHandler.CreateTorneio)
 		torneioRoutes.GET("", torneioHandler.GetTorneios)
 		torneioRoutes.GET("/:id", torneioHandler.GetTorneioByID)
-		torneioRoutes.PUT("/:id", torneioHandler.UpdateTorneio)
-		torneioRoutes.DELETE("/:id", torneioHandler.DeleteTorneio)
-		torneioRoutes.POST("/:id/inscrever", torneioHandler.InscreverJogador)
+		torneioRoutes.PUT("/:id", organizaTorneio, torneioHandler.UpdateTorneio)
+		torneioRoutes.DELETE("/:id", criadorTorneio, torneioHandler.DeleteTorneio)
+		torneioRoutes.POST("/:id/inscrever", inscrever, torneioHandler.InscreverJogador)
 		torneioRoutes.GET("/:id/inscricoes", torneioHandler.ListarInscricoes) // <-- NOVA ROTA
+		torneioRoutes.POST("/:id/jogos", organizaTorneio, jogoHandler.GerarJogosTorneio)
+		torneioRoutes.POST("/:id/chaveamento", organizaTorneio, chaveamentoHandler.CreateChaveamento)
+		torneioRoutes.GET("/:id/chaveamento", chaveamentoHandler.GetChaveamento)
+		torneioRoutes.POST("/:id/playoffs", organizaTorneio, chaveamentoHandler.CreatePlayoffs)
+		torneioRoutes.GET("/:id/classificacao", grupoHandler.GetClassificacaoCategoria)
+		torneioRoutes.POST("/:id/agenda", organizaTorneio, jogoHandler.AgendarJogos)
+		torneioRoutes.GET("/:id/formatos", torneioHandler.GetFormatosPartida)
+		torneioRoutes.PUT("/:id/formatos", organizaTorneio, torneioHandler.SalvarFormatoPartida)
+		torneioRoutes.DELETE("/:id/formatos", organizaTorneio, torneioHandler.DeleteFormatoPartida)
+		torneioRoutes.GET("/:id/organizadores", torneioHandler.GetOrganizadores)
+		torneioRoutes.POST("/:id/organizadores", criadorTorneio, torneioHandler.AdicionarOrganizador)
+		torneioRoutes.DELETE("/:id/organizadores/:id_usuario", criadorTorneio, torneioHandler.RemoverOrganizador)
 	}
 
+	// Transmissão ao vivo dos torneios (pública): telas de espectadores usam o EventSource do
+	// navegador, que não envia o cabeçalho Authorization.
+	router.GET("/torneios/:id/transmissao", transmissaoHandler.TransmitirTorneio)
+
 	// Rotas de Esportes
 	esporteRoutes := router.Group("/esportes")
-	esporteRoutes.Use(authMiddleware.MiddlewareFunc())
+	esporteRoutes.Use(authMiddleware.MiddlewareFunc(), consultar)
 	{
 		esporteRoutes.GET("", esporteHandler.GetEsportes)
+		esporteRoutes.GET("/:id/rating", esporteHandler.GetConfiguracaoRating)
+		esporteRoutes.PUT("/:id/rating", configurarEsportes, esporteHandler.UpdateConfiguracaoRating)
 	}
 
 	// Rotas de Grupos
 	grupoRoutes := router.Group("/grupos")
-	grupoRoutes.Use(authMiddleware.MiddlewareFunc())
+	grupoRoutes.Use(authMiddleware.MiddlewareFunc(), consultar)
 	{
-		grupoRoutes.POST("/:id/criar", grupoHandler.CreateGrupos)
+		grupoRoutes.POST("/:id/criar", organizaTorneio, grupoHandler.CreateGrupos)
 		grupoRoutes.GET("/:id/vencedores", grupoHandler.DefinirVencedoresGrupo)
+		grupoRoutes.GET("/:id/classificacao", grupoHandler.GetClassificacaoGrupo)
+		grupoRoutes.POST("/:id/jogos", organizaGrupo, jogoHandler.GerarJogosGrupo)
+	}
+
+	// Rotas de Jogos
+	jogoRoutes := router.Group("/jogos")
+	jogoRoutes.Use(authMiddleware.MiddlewareFunc(), consultar)
+	{
+		jogoRoutes.POST("", gerenciarTorneios, jogoHandler.CreateJogo)
+		jogoRoutes.GET("", jogoHandler.GetJogos)
+		jogoRoutes.GET("/:id", jogoHandler.GetJogoByID)
+		jogoRoutes.PUT("/:id", organizaJogo, jogoHandler.UpdateJogo)
+		jogoRoutes.DELETE("/:id", organizaJogo, jogoHandler.DeleteJogo)
+		jogoRoutes.PUT("/:id/resultado", resultadoJogo, jogoHandler.RegistrarResultado)
+		jogoRoutes.GET("/:id/sets", jogoHandler.GetSets)
+		jogoRoutes.PUT("/:id/sets", resultadoJogo, jogoHandler.SalvarSets)
+		jogoRoutes.GET("/:id/placar-ao-vivo", jogoHandler.GetPlacarAoVivo)
+		jogoRoutes.POST("/:id/eventos", resultadoJogo, jogoHandler.RegistrarEvento)
+		jogoRoutes.DELETE("/:id/eventos/ultimo", resultadoJogo, jogoHandler.DesfazerUltimoEvento)
+		jogoRoutes.POST("/:id/encerrar", resultadoJogo, jogoHandler.EncerrarPlacarAoVivo)
+	}
+
+	// Rotas de Duplas
+	duplaRoutes := router.Group("/duplas")
+	duplaRoutes.Use(authMiddleware.MiddlewareFunc(), consultar)
+	{
+		duplaRoutes.POST("", inscrever, duplaHandler.CreateDupla)
+		duplaRoutes.GET("", duplaHandler.GetDuplas)
+		duplaRoutes.GET("/:id", duplaHandler.GetDuplaByID)
+		duplaRoutes.PUT("/:id", integraDupla, duplaHandler.RenomearDupla)
+		duplaRoutes.DELETE("/:id", integraDupla, duplaHandler.DeleteDupla)
+	}
+
+	// Rotas de Rankings
+	rankingRoutes := router.Group("/rankings")
+	rankingRoutes.Use(authMiddleware.MiddlewareFunc(), consultar)
+	{
+		rankingRoutes.GET("", rankingHandler.GetRanking)
+	}
+
+	// Rotas de Circuitos
+	circuitoRoutes := router.Group("/circuitos")
+	circuitoRoutes.Use(authMiddleware.MiddlewareFunc(), consultar)
+	{
+		circuitoRoutes.POST("", gerenciarTorneios, circuitoHandler.CreateCircuito)
+		circuitoRoutes.GET("", circuitoHandler.GetCircuitos)
+		circuitoRoutes.GET("/:id", circuitoHandler.GetCircuitoByID)
+		circuitoRoutes.PUT("/:id", criadorCircuito, circuitoHandler.UpdateCircuito)
+		circuitoRoutes.DELETE("/:id", criadorCircuito, circuitoHandler.DeleteCircuito)
+		circuitoRoutes.POST("/:id/torneios", criadorCircuito, circuitoHandler.AdicionarTorneioCircuito)
+		circuitoRoutes.DELETE("/:id/torneios/:id_torneio", criadorCircuito, circuitoHandler.RemoverTorneioCircuito)
+		circuitoRoutes.GET("/:id/classificacao", circuitoHandler.GetClassificacaoCircuito)
+	}
+
+	// Rotas de Clubes
+	clubeRoutes := router.Group("/clubes")
+	clubeRoutes.Use(authMiddleware.MiddlewareFunc(), consultar)
+	{
+		clubeRoutes.POST("", gerenciarClubes, clubeHandler.CreateClube)
+		clubeRoutes.GET("", clubeHandler.GetClubes)
+		clubeRoutes.GET("/:id", clubeHandler.GetClubeByID)
+		clubeRoutes.PUT("/:id", organizaClube, clubeHandler.UpdateClube)
+		clubeRoutes.DELETE("/:id", criadorClube, clubeHandler.DeleteClube)
+		clubeRoutes.GET("/:id/organizadores", clubeHandler.GetOrganizadores)
+		clubeRoutes.POST("/:id/organizadores", criadorClube, clubeHandler.AdicionarOrganizador)
+		clubeRoutes.DELETE("/:id/organizadores/:id_usuario", criadorClube, clubeHandler.RemoverOrganizador)
+	}
+
+	// Rotas administrativas
+	adminRoutes := router.Group("/admin")
+	adminRoutes.Use(authMiddleware.MiddlewareFunc(), middleware.RequerPermissao(middleware.Administrar))
+	{
+		adminRoutes.POST("/scouts/recalcular", scoutHandler.RecalcularScouts)
 	}
 }
@@ -0,0 +1,327 @@
+package routes
+
+import (
+	"competitions/handlers"
+	"competitions/middleware"
+	"competitions/models"
+	"competitions/repository"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	segredoTeste = "segredo-de-teste"
+	idDono       = 1 // Criador dos torneios, jogos, clubes e circuitos e integrante das duplas dos stubs
+	idOutro      = 2 // Usuário ativo sem vínculo com os recursos
+	idInativo    = 3 // Usuário com a conta desativada
+	idCoorg      = 4 // Coorganizador dos torneios e clubes dos stubs, sem ser o criador
+)
+
+// Os stubs embutem a interface do repositório e implementam apenas os métodos usados pelas
+// rotas testadas; chamar qualquer outro método causa pânico e falha o teste.
+
+type stubUsuarios struct{ repository.UsuarioRepository }
+
+func (stubUsuarios) FindVersaoToken(_ context.Context, id uint) (int, bool, error) {
+	switch id {
+	case idDono, idOutro, idCoorg:
+		return 1, true, nil
+	case idInativo:
+		return 1, false, nil
+	}
+	return 0, false, pgx.ErrNoRows
+}
+
+func (stubUsuarios) FindAll(context.Context) ([]models.Usuario, error) {
+	return []models.Usuario{{ID: idDono}}, nil
+}
+
+func (stubUsuarios) FindByID(_ context.Context, id int) (*models.Usuario, error) {
+	return &models.Usuario{ID: uint(id)}, nil
+}
+
+type stubTorneios struct{ repository.TorneioRepository }
+
+func (stubTorneios) FindAll(context.Context) ([]models.Torneio, error) {
+	return []models.Torneio{}, nil
+}
+
+func (stubTorneios) EhOrganizador(_ context.Context, _, usuarioID int) (bool, error) {
+	return usuarioID == idDono || usuarioID == idCoorg, nil
+}
+
+func (stubTorneios) EhCriador(_ context.Context, _, usuarioID int) (bool, error) {
+	return usuarioID == idDono, nil
+}
+
+func (stubTorneios) DeleteFormatoPartida(context.Context, int, *int) (int64, error) {
+	return 1, nil
+}
+
+func (stubTorneios) Delete(context.Context, int) (int64, error) {
+	return 1, nil
+}
+
+type stubClubes struct{ repository.ClubeRepository }
+
+func (stubClubes) EhOrganizador(_ context.Context, _, usuarioID int) (bool, error) {
+	return usuarioID == idDono || usuarioID == idCoorg, nil
+}
+
+func (stubClubes) EhCriador(_ context.Context, _, usuarioID int) (bool, error) {
+	return usuarioID == idDono, nil
+}
+
+func (stubClubes) Update(context.Context, int, models.ClubeInput) (int64, error) {
+	return 1, nil
+}
+
+func (stubClubes) Delete(context.Context, int) (int64, error) {
+	return 1, nil
+}
+
+type stubCircuitos struct{ repository.CircuitoRepository }
+
+func (stubCircuitos) EhCriador(_ context.Context, _, usuarioID int) (bool, error) {
+	return usuarioID == idDono, nil
+}
+
+// EhOrganizadorTorneio considera idDono organizador apenas do torneio 1.
+func (stubCircuitos) EhOrganizadorTorneio(_ context.Context, torneioID, usuarioID int) (bool, error) {
+	return torneioID == 1 && usuarioID == idDono, nil
+}
+
+func (stubCircuitos) Delete(context.Context, int) (int64, error) {
+	return 1, nil
+}
+
+func (stubCircuitos) AdicionarTorneio(context.Context, int, models.TorneioCircuitoInput) error {
+	return nil
+}
+
+type stubGrupos struct{ repository.GrupoRepository }
+
+func (stubGrupos) EhOrganizador(_ context.Context, _, usuarioID int) (bool, error) {
+	return usuarioID == idDono, nil
+}
+
+type stubJogos struct{ repository.JogoRepository }
+
+func (stubJogos) EhOrganizador(_ context.Context, _, usuarioID int) (bool, error) {
+	return usuarioID == idDono, nil
+}
+
+func (stubJogos) DesfazerUltimoEvento(_ context.Context, jogoID int) (models.PlacarAoVivo, error) {
+	return models.PlacarAoVivo{JogoID: jogoID}, nil
+}
+
+type stubDuplas struct{ repository.DuplaRepository }
+
+func (stubDuplas) IntegraDupla(_ context.Context, _, usuarioID int) (bool, error) {
+	return usuarioID == idDono, nil
+}
+
+func (stubDuplas) Delete(context.Context, int) (int64, error) {
+	return 1, nil
+}
+
+type stubEsportes struct{ repository.EsporteRepository }
+
+func (stubEsportes) UpdateConfiguracaoRating(context.Context, int, models.ConfiguracaoRatingInput) (int64, error) {
+	return 1, nil
+}
+
+type stubScouts struct{}
+
+func (stubScouts) Recalcular(context.Context, bool) (models.RelatorioRecalculo, error) {
+	return models.RelatorioRecalculo{}, nil
+}
+
+// novoRouterTeste registra as rotas da aplicação com repositórios stub e retorna também o
+// middleware JWT, usado para emitir os tokens dos testes.
+func novoRouterTeste() (*gin.Engine, func(id uint, tipo string, versao int) string) {
+	gin.SetMode(gin.TestMode)
+	usuarios := stubUsuarios{}
+	torneios := stubTorneios{}
+	jogos := stubJogos{}
+	authHandler := handlers.NewAuthHandler(usuarios)
+
+	router := gin.New()
+	RegisterRoutes(router,
+		handlers.NewUsuarioHandler(usuarios),
+		handlers.NewTorneioHandler(torneios),
+		handlers.NewEsporteHandler(stubEsportes{}),
+		handlers.NewGrupoHandler(stubGrupos{}),
+		handlers.NewJogoHandler(jogos),
+		handlers.NewChaveamentoHandler(nil),
+		handlers.NewDuplaHandler(stubDuplas{}),
+		handlers.NewScoutHandler(stubScouts{}),
+		handlers.NewRankingHandler(nil),
+		handlers.NewCircuitoHandler(stubCircuitos{}),
+		handlers.NewClubeHandler(stubClubes{}),
+		handlers.NewTransmissaoHandler(nil),
+		handlers.NewPerfilHandler(usuarios, jogos),
+		authHandler,
+		segredoTeste,
+	)
+
+	mw := middleware.AuthMiddleware(segredoTeste, authHandler)
+	token := func(id uint, tipo string, versao int) string {
+		t, _, err := mw.TokenGenerator(&models.Usuario{ID: id, Tipo: tipo, VersaoToken: versao})
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+	return router, token
+}
+
+// rotaProtegida é uma rota da API com a ação exigida. Rotas de recurso também exigem que o
+// usuário seja organizador ou integrante do recurso (ou administrador).
+type rotaProtegida struct {
+	metodo, caminho, corpo string
+	acao                   middleware.Acao
+	recurso                bool
+}
+
+const corpoClube = `{"nome":"Clube","telefone":"11999990000","id_cidade":1,"id_estado":1,"id_pais":1}`
+
+var rotasProtegidas = []rotaProtegida{
+	{http.MethodGet, "/torneios", "", middleware.Consultar, false},
+	{http.MethodGet, "/me", "", middleware.Consultar, false},
+	{http.MethodGet, "/usuarios", "", middleware.GerenciarUsuarios, false},
+	{http.MethodDelete, "/duplas/1", "", middleware.Inscrever, true},
+	{http.MethodDelete, "/torneios/1", "", middleware.GerenciarTorneios, true},
+	{http.MethodDelete, "/torneios/1/formatos", "", middleware.GerenciarTorneios, true},
+	{http.MethodDelete, "/jogos/1/eventos/ultimo", "", middleware.RegistrarResultados, true},
+	{http.MethodPut, "/clubes/1", corpoClube, middleware.GerenciarClubes, true},
+	{http.MethodDelete, "/clubes/1", "", middleware.GerenciarClubes, true},
+	{http.MethodDelete, "/circuitos/1", "", middleware.GerenciarTorneios, true},
+	{http.MethodPost, "/circuitos/1/torneios", `{"id_torneio":1}`, middleware.GerenciarTorneios, true},
+	{http.MethodPut, "/esportes/1/rating", `{"algoritmo":"elo"}`, middleware.ConfigurarEsportes, false},
+	{http.MethodPost, "/admin/scouts/recalcular", "", middleware.Administrar, false},
+}
+
+var tiposUsuario = []string{
+	models.TipoUsuario, models.TipoJogador, models.TipoGestorClube, models.TipoGestorTorneio, models.TipoAdmin,
+}
+
+func executar(router *gin.Engine, rota rotaProtegida, token string) int {
+	req := httptest.NewRequest(rota.metodo, rota.caminho, strings.NewReader(rota.corpo))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestPermissoesPorTipoDeUsuario(t *testing.T) {
+	router, token := novoRouterTeste()
+
+	for _, rota := range rotasProtegidas {
+		for _, tipo := range tiposUsuario {
+			t.Run(fmt.Sprintf("%s %s como %s", rota.metodo, rota.caminho, tipo), func(t *testing.T) {
+				code := executar(router, rota, token(idDono, tipo, 1))
+				permitido := middleware.Permite(tipo, rota.acao)
+				switch {
+				case permitido && (code < 200 || code > 299):
+					t.Errorf("status = %d, esperado 2xx", code)
+				case !permitido && code != http.StatusForbidden:
+					t.Errorf("status = %d, esperado %d", code, http.StatusForbidden)
+				}
+			})
+		}
+	}
+}
+
+func TestPermissoesSobreRecursoDeOutroUsuario(t *testing.T) {
+	router, token := novoRouterTeste()
+
+	for _, rota := range rotasProtegidas {
+		if !rota.recurso {
+			continue
+		}
+		for _, tipo := range tiposUsuario {
+			if !middleware.Permite(tipo, rota.acao) {
+				continue
+			}
+			t.Run(fmt.Sprintf("%s %s como %s sem vínculo", rota.metodo, rota.caminho, tipo), func(t *testing.T) {
+				code := executar(router, rota, token(idOutro, tipo, 1))
+				if tipo == models.TipoAdmin {
+					if code < 200 || code > 299 {
+						t.Errorf("status = %d, esperado 2xx para administrador", code)
+					}
+				} else if code != http.StatusForbidden {
+					t.Errorf("status = %d, esperado %d", code, http.StatusForbidden)
+				}
+			})
+		}
+	}
+}
+
+func TestPermissoesDeCoorganizador(t *testing.T) {
+	router, token := novoRouterTeste()
+
+	casos := []struct {
+		rota     rotaProtegida
+		tipo     string
+		esperado int
+	}{
+		{rotaProtegida{metodo: http.MethodDelete, caminho: "/torneios/1/formatos"}, models.TipoGestorTorneio, http.StatusOK},
+		{rotaProtegida{metodo: http.MethodDelete, caminho: "/torneios/1"}, models.TipoGestorTorneio, http.StatusForbidden},
+		{rotaProtegida{metodo: http.MethodPut, caminho: "/clubes/1", corpo: corpoClube}, models.TipoGestorClube, http.StatusOK},
+		{rotaProtegida{metodo: http.MethodDelete, caminho: "/clubes/1"}, models.TipoGestorClube, http.StatusForbidden},
+	}
+	for _, caso := range casos {
+		t.Run(fmt.Sprintf("%s %s", caso.rota.metodo, caso.rota.caminho), func(t *testing.T) {
+			if code := executar(router, caso.rota, token(idCoorg, caso.tipo, 1)); code != caso.esperado {
+				t.Errorf("status = %d, esperado %d", code, caso.esperado)
+			}
+		})
+	}
+}
+
+func TestIncluirTorneioNoCircuitoExigeOrganizarOTorneio(t *testing.T) {
+	router, token := novoRouterTeste()
+	rota := rotaProtegida{metodo: http.MethodPost, caminho: "/circuitos/1/torneios", corpo: `{"id_torneio":2}`}
+
+	if code := executar(router, rota, token(idDono, models.TipoGestorTorneio, 1)); code != http.StatusForbidden {
+		t.Errorf("criador do circuito sem organizar o torneio: status = %d, esperado %d", code, http.StatusForbidden)
+	}
+	if code := executar(router, rota, token(idOutro, models.TipoAdmin, 1)); code != http.StatusCreated {
+		t.Errorf("administrador: status = %d, esperado %d", code, http.StatusCreated)
+	}
+}
+
+func TestRotasProtegidasSemTokenValido(t *testing.T) {
+	router, token := novoRouterTeste()
+
+	casos := []struct {
+		nome  string
+		token string
+	}{
+		{"sem token", ""},
+		{"token inválido", "nao-e-um-jwt"},
+		{"token revogado", token(idDono, models.TipoAdmin, 0)},
+		{"usuário inativo", token(idInativo, models.TipoAdmin, 1)},
+		{"usuário removido", token(99, models.TipoAdmin, 1)},
+	}
+	for _, rota := range rotasProtegidas {
+		for _, caso := range casos {
+			t.Run(fmt.Sprintf("%s %s %s", rota.metodo, rota.caminho, caso.nome), func(t *testing.T) {
+				if code := executar(router, rota, caso.token); code != http.StatusUnauthorized {
+					t.Errorf("status = %d, esperado %d", code, http.StatusUnauthorized)
+				}
+			})
+		}
+	}
+}
@@ -62,7 +62,10 @@ CREATE TABLE IF NOT EXISTS usuarios (
   telefone VARCHAR(20) NOT NULL,
   instagram VARCHAR(50),
   criado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-  ativo BOOLEAN NOT NULL DEFAULT TRUE
+  ativo BOOLEAN NOT NULL DEFAULT TRUE,
+  -- Versão dos tokens JWT do usuário, gravada no token no login. Incrementá-la revoga todos os
+  -- tokens já emitidos (logout, troca de senha, desativação ou mudança de tipo).
+  versao_token INT NOT NULL DEFAULT 1
 );
 
 -- SEÇÃO 3: TABELA DE NÍVEIS
@@ -88,7 +91,11 @@ CREATE TABLE IF NOT EXISTS categorias (
 -- SEÇÃO 6: TABELA DE ESPORTES
 CREATE TABLE IF NOT EXISTS esportes (
   id SERIAL PRIMARY KEY,
-  nome esporte_enum NOT NULL UNIQUE
+  nome esporte_enum NOT NULL UNIQUE,
+  duracao_estimada_minutos INT NOT NULL DEFAULT 60, -- Usada pelo agendamento dos jogos
+  algoritmo_rating VARCHAR(10) NOT NULL DEFAULT 'elo' CHECK (algoritmo_rating IN ('elo', 'glicko2')),
+  fator_k_rating INT NOT NULL DEFAULT 32 CHECK (fator_k_rating > 0),              -- Elo
+  tau_glicko DOUBLE PRECISION NOT NULL DEFAULT 0.5 CHECK (tau_glicko > 0)          -- Glicko-2
 );
 
 -- SEÇÃO 7: TABELA DE PAÍSES
@@ -120,7 +127,6 @@ CREATE TABLE IF NOT EXISTS scouts (
   id SERIAL PRIMARY KEY, -- Esta tabela parece ser para estatísticas de jogadores
   vitorias INT NOT NULL DEFAULT 0,
   derrotas INT NOT NULL DEFAULT 0,
-  rating INT NOT NULL DEFAULT 0,
   titulos INT NOT NULL DEFAULT 0
 );
 
@@ -150,6 +156,21 @@ CREATE TABLE IF NOT EXISTS jogadores_esportes (
   PRIMARY KEY (id_jogador, id_esporte)
 );
 
+-- SEÇÃO 11-A: RATINGS DOS JOGADORES POR ESPORTE E MODALIDADE
+-- Cada jogador tem um rating independente por esporte e por modalidade (simples/duplas).
+-- A linha é criada no primeiro jogo encerrado; até lá, vale o rating inicial (1500).
+CREATE TABLE IF NOT EXISTS ratings_jogadores (
+  id_jogador INT NOT NULL REFERENCES jogadores(id) ON DELETE CASCADE,
+  id_esporte INT NOT NULL REFERENCES esportes(id) ON DELETE CASCADE,
+  tipo_modalidade tipo_modalidade_enum NOT NULL,
+  rating INT NOT NULL DEFAULT 1500,
+  desvio_rating DOUBLE PRECISION NOT NULL DEFAULT 350, -- Glicko-2: incerteza do rating
+  volatilidade DOUBLE PRECISION NOT NULL DEFAULT 0.06, -- Glicko-2
+  jogos INT NOT NULL DEFAULT 0,
+  atualizado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id_jogador, id_esporte, tipo_modalidade)
+);
+
 -- SEÇÃO 12: TABELA DE CLUBES
 CREATE TABLE IF NOT EXISTS clubes (
   id SERIAL PRIMARY KEY,
@@ -162,10 +183,19 @@ CREATE TABLE IF NOT EXISTS clubes (
   id_estado INT NOT NULL REFERENCES estados(id) ON DELETE CASCADE, -- Nova coluna
   id_pais INT NOT NULL REFERENCES paises(id) ON DELETE CASCADE,
   quantidade INT NOT NULL DEFAULT 0,
+  id_usuario_criador INT REFERENCES usuarios(id) ON DELETE SET NULL, -- Dono do clube, registrado na criação
   ativo BOOLEAN NOT NULL DEFAULT TRUE,
   criado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
+-- SEÇÃO 12-A: COORGANIZADORES DOS CLUBES
+-- Além do criador, os coorganizadores (e os administradores) podem alterar o clube.
+CREATE TABLE IF NOT EXISTS clubes_organizadores (
+  id_clube INT NOT NULL REFERENCES clubes(id) ON DELETE CASCADE,
+  id_usuario INT NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+  PRIMARY KEY (id_clube, id_usuario)
+);
+
 -- SEÇÃO 13: TABELA DE RELACIONAMENTO CLUBES_USUARIOS (N:N) - Jogadores em Clubes
 CREATE TABLE IF NOT EXISTS clubes_usuarios (
   id_clube INT NOT NULL REFERENCES clubes(id) ON DELETE CASCADE,
@@ -181,15 +211,43 @@ CREATE TABLE IF NOT EXISTS torneios (
   nome VARCHAR(100) NOT NULL,
   descricao TEXT,
   quantidade_quadras INT NOT NULL DEFAULT 1,
+  criterios_desempate TEXT[] NOT NULL DEFAULT '{vitorias,confronto_direto,razao_sets,razao_pontos}', -- Ordem de desempate da fase de grupos
   criado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, -- Adicionada vírgula
   inicio TIMESTAMP NOT NULL, -- Renomeado de data_inicio
   fim TIMESTAMP NOT NULL,    -- Renomeado de data_fim
   id_cidade INT NOT NULL REFERENCES cidades(id) ON DELETE CASCADE, -- Nova coluna
   id_estado INT NOT NULL REFERENCES estados(id) ON DELETE CASCADE, -- Nova coluna
   id_pais INT NOT NULL REFERENCES paises(id) ON DELETE CASCADE,     -- Nova coluna
+  id_usuario_criador INT REFERENCES usuarios(id) ON DELETE SET NULL, -- Dono do torneio, registrado na criação
   ativo BOOLEAN NOT NULL DEFAULT TRUE
 );
 
+-- SEÇÃO 14-A: FORMATOS DE PARTIDA (por torneio e, opcionalmente, por categoria)
+-- Sem categoria, é o formato geral do torneio; com categoria, sobrepõe o formato geral.
+-- Colunas nulas mantêm a regra oficial do esporte.
+CREATE TABLE IF NOT EXISTS formatos_partida (
+  id SERIAL PRIMARY KEY,
+  id_torneio INT NOT NULL REFERENCES torneios(id) ON DELETE CASCADE,
+  id_categoria INT REFERENCES categorias(id) ON DELETE CASCADE,
+  melhor_de INT CHECK (melhor_de IN (1, 3, 5)),
+  games_por_set INT CHECK (games_por_set > 0),
+  pontos_por_game INT CHECK (pontos_por_game > 0),
+  sem_vantagem BOOLEAN,            -- No-ad: ponto decisivo em 40 x 40
+  tiebreak_empate BOOLEAN,         -- Tiebreak no empate em games (ex.: 6 x 6)
+  super_tiebreak_decisivo BOOLEAN  -- Super tiebreak até 10 no lugar do último set
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_formatos_partida_torneio ON formatos_partida(id_torneio) WHERE id_categoria IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_formatos_partida_categoria ON formatos_partida(id_torneio, id_categoria) WHERE id_categoria IS NOT NULL;
+
+-- SEÇÃO 14-B: COORGANIZADORES DOS TORNEIOS
+-- Além do criador, os coorganizadores (e os administradores) podem alterar o torneio, gerar
+-- grupos, jogos e chaves e registrar resultados.
+CREATE TABLE IF NOT EXISTS torneios_organizadores (
+  id_torneio INT NOT NULL REFERENCES torneios(id) ON DELETE CASCADE,
+  id_usuario INT NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+  PRIMARY KEY (id_torneio, id_usuario)
+);
+
 -- SEÇÃO 13: TABELA DE DUPLAS
 CREATE TABLE IF NOT EXISTS duplas (
   id SERIAL PRIMARY KEY,
@@ -220,6 +278,7 @@ CREATE TABLE IF NOT EXISTS jogadores_torneios (
 -- SEÇÃO 15: TABELA DE GRUPOS (de um torneio/categoria)
 CREATE TABLE IF NOT EXISTS grupos (
   id SERIAL PRIMARY KEY,
+  id_torneio INT NOT NULL REFERENCES torneios(id) ON DELETE CASCADE,
   id_categoria INT NOT NULL REFERENCES categorias(id) ON DELETE CASCADE,
   nome VARCHAR(50) NOT NULL
 );
@@ -234,6 +293,9 @@ CREATE TABLE IF NOT EXISTS grupo_jogadores_torneios (
 -- SEÇÃO 17: TABELA DE RODADAS (de um torneio/grupo)
 CREATE TABLE IF NOT EXISTS rodadas (
   id SERIAL PRIMARY KEY,
+  id_torneio INT REFERENCES torneios(id) ON DELETE CASCADE,
+  id_grupo INT REFERENCES grupos(id) ON DELETE CASCADE, -- Nulo para rodadas fora da fase de grupos
+  numero INT, -- Ordem da rodada dentro do grupo/torneio
   nome VARCHAR(50) NOT NULL
 );
 
@@ -241,7 +303,7 @@ CREATE TABLE IF NOT EXISTS rodadas (
 CREATE TABLE IF NOT EXISTS jogos (
   id SERIAL PRIMARY KEY, -- Jogos/Partidas
   id_torneio INT NOT NULL REFERENCES torneios(id) ON DELETE CASCADE,
-  id_grupo INT NOT NULL REFERENCES grupos(id) ON DELETE CASCADE,
+  id_grupo INT REFERENCES grupos(id) ON DELETE CASCADE, -- Nulo para jogos de chaveamento (eliminatória)
   id_rodada INT NOT NULL REFERENCES rodadas(id) ON DELETE CASCADE,
   id_jogador_torneio1 INT REFERENCES jogadores_torneios(id) ON DELETE SET NULL,
   id_jogador_torneio2 INT REFERENCES jogadores_torneios(id) ON DELETE SET NULL,
@@ -258,6 +320,25 @@ CREATE TABLE IF NOT EXISTS jogos (
   eh_final_campeonato BOOLEAN DEFAULT FALSE
 );
 
+-- SEÇÃO 18-A: HISTÓRICO DE RATINGS
+-- Uma linha por jogador a cada jogo que alterou o seu rating, com o adversário (jogador em
+-- simples, dupla em duplas) e o rating médio do lado adversário antes do jogo.
+CREATE TABLE IF NOT EXISTS historico_ratings (
+  id SERIAL PRIMARY KEY,
+  id_jogador INT NOT NULL REFERENCES jogadores(id) ON DELETE CASCADE,
+  id_esporte INT NOT NULL REFERENCES esportes(id) ON DELETE CASCADE,
+  tipo_modalidade tipo_modalidade_enum NOT NULL,
+  id_jogo INT REFERENCES jogos(id) ON DELETE SET NULL,
+  data_jogo TIMESTAMP NOT NULL,
+  rating_antes INT NOT NULL,
+  rating_depois INT NOT NULL,
+  delta INT NOT NULL,
+  id_jogador_adversario INT REFERENCES jogadores(id) ON DELETE SET NULL,
+  id_dupla_adversaria INT REFERENCES duplas(id) ON DELETE SET NULL,
+  rating_adversario INT NOT NULL,
+  criado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
 -- SEÇÃO 19: TABELA DE SETS (scores por set)
 CREATE TABLE IF NOT EXISTS sets (
   id SERIAL PRIMARY KEY,
@@ -269,6 +350,95 @@ CREATE TABLE IF NOT EXISTS sets (
   UNIQUE (id_jogo, set_numero) -- Garante um score por set por jogo
 );
 
+-- SEÇÃO 19-A: TABELA DE CHAVEAMENTOS (eliminatória simples de um torneio/categoria)
+CREATE TABLE IF NOT EXISTS chaveamentos (
+  id SERIAL PRIMARY KEY,
+  id_torneio INT NOT NULL REFERENCES torneios(id) ON DELETE CASCADE,
+  id_categoria INT NOT NULL REFERENCES categorias(id) ON DELETE CASCADE,
+  tipo_modalidade tipo_modalidade_enum NOT NULL DEFAULT 'simples',
+  tamanho INT NOT NULL, -- Quantidade de posições na primeira rodada (potência de 2)
+  criado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (id_torneio, id_categoria)
+);
+
+-- SEÇÃO 19-B: TABELA DE PARTIDAS DO CHAVEAMENTO
+-- Cada linha é uma posição fixa da chave. A rodada 1 é a primeira rodada e a partida de
+-- posição P avança o vencedor para a posição (P+1)/2 da rodada seguinte.
+-- Os lados referenciam inscrições (jogadores_torneios); um lado nulo na rodada 1 é um "bye".
+CREATE TABLE IF NOT EXISTS chaveamento_partidas (
+  id SERIAL PRIMARY KEY,
+  id_chaveamento INT NOT NULL REFERENCES chaveamentos(id) ON DELETE CASCADE,
+  rodada INT NOT NULL,
+  posicao INT NOT NULL,
+  id_rodada INT NOT NULL REFERENCES rodadas(id) ON DELETE CASCADE,
+  id_inscricao1 INT REFERENCES jogadores_torneios(id) ON DELETE SET NULL,
+  id_inscricao2 INT REFERENCES jogadores_torneios(id) ON DELETE SET NULL,
+  seed1 INT,
+  seed2 INT,
+  id_inscricao_vencedora INT REFERENCES jogadores_torneios(id) ON DELETE SET NULL,
+  id_jogo INT REFERENCES jogos(id) ON DELETE SET NULL,
+  UNIQUE (id_chaveamento, rodada, posicao)
+);
+
+-- SEÇÃO 19-C: CIRCUITOS (temporadas de ranking que agrupam torneios de um esporte)
+-- Cada torneio do circuito concede pontos conforme a fase alcançada na chave de cada categoria.
+-- melhores_resultados limita a soma aos N melhores resultados de cada jogador (nulo soma todos);
+-- pontos_participacao é concedido a quem não chegou a uma fase pontuada (ex.: eliminados nos grupos).
+CREATE TABLE IF NOT EXISTS circuitos (
+  id SERIAL PRIMARY KEY,
+  id_esporte INT NOT NULL REFERENCES esportes(id) ON DELETE CASCADE,
+  nome VARCHAR(100) NOT NULL,
+  temporada INT NOT NULL,
+  melhores_resultados INT CHECK (melhores_resultados > 0),
+  pontos_participacao INT NOT NULL DEFAULT 0 CHECK (pontos_participacao >= 0),
+  id_usuario_criador INT REFERENCES usuarios(id) ON DELETE SET NULL, -- Dono do circuito, registrado na criação
+  criado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tabela de pontos do circuito. A colocação é a quantidade de participantes da rodada em que o
+-- jogador foi eliminado: 1 = campeão, 2 = finalista, 4 = semifinal, 8 = quartas de final...
+CREATE TABLE IF NOT EXISTS circuitos_pontuacoes (
+  id_circuito INT NOT NULL REFERENCES circuitos(id) ON DELETE CASCADE,
+  colocacao INT NOT NULL CHECK (colocacao > 0),
+  pontos INT NOT NULL CHECK (pontos >= 0),
+  PRIMARY KEY (id_circuito, colocacao)
+);
+
+CREATE TABLE IF NOT EXISTS circuitos_torneios (
+  id_circuito INT NOT NULL REFERENCES circuitos(id) ON DELETE CASCADE,
+  id_torneio INT NOT NULL REFERENCES torneios(id) ON DELETE CASCADE,
+  PRIMARY KEY (id_circuito, id_torneio)
+);
+
+-- SEÇÃO 19-D: EVENTOS DE PONTUAÇÃO (placar ao vivo, ponto a ponto)
+-- O placar ao vivo é recalculado reproduzindo os eventos em ordem; desfazer remove o último evento.
+-- 'let' repete o ponto e não possui lado; 'ponto' e 'falta' indicam o lado que venceu o ponto ou cometeu a falta.
+CREATE TABLE IF NOT EXISTS eventos_pontuacao (
+  id SERIAL PRIMARY KEY,
+  id_jogo INT NOT NULL REFERENCES jogos(id) ON DELETE CASCADE,
+  tipo VARCHAR(10) NOT NULL CHECK (tipo IN ('ponto', 'falta', 'let')),
+  lado INT CHECK (lado IN (1, 2)),
+  criado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT chk_evento_pontuacao_lado CHECK ((tipo = 'let') = (lado IS NULL))
+);
+
+-- SEÇÃO 19-E: TRANSMISSÃO AO VIVO (eventos enviados aos espectadores por Server-Sent Events)
+-- Cada alteração de placar, situação ou quadra de um jogo gera um evento do torneio. A posição de um
+-- evento na transmissão é (transacao, id): a leitura só entrega eventos de transações anteriores à mais
+-- antiga ainda em andamento, de modo que um cliente que retoma a partir da última posição recebida
+-- não perde eventos gravados por transações concluídas fora de ordem.
+CREATE TABLE IF NOT EXISTS eventos_transmissao (
+  id BIGSERIAL PRIMARY KEY,
+  transacao XID8 NOT NULL DEFAULT pg_current_xact_id(),
+  id_torneio INT NOT NULL REFERENCES torneios(id) ON DELETE CASCADE,
+  id_jogo INT REFERENCES jogos(id) ON DELETE CASCADE,
+  tipo VARCHAR(20) NOT NULL CHECK (tipo IN ('placar', 'resultado', 'situacao', 'quadra')),
+  quadra VARCHAR(100),          -- Quadra do jogo quando o evento foi gerado
+  quadra_anterior VARCHAR(100), -- Em eventos 'quadra', a quadra de onde o jogo saiu
+  dados JSONB NOT NULL,
+  criado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
 -- SEÇÃO 20: CONSTRAINTS ADICIONAIS (ALTER TABLE)
 -- Adicionar uma constraint para garantir a consistência dos dados de jogadores em torneios
 -- Esta constraint garante que, para jogos 'simples', os campos de jogador do torneio sejam preenchidos e os de dupla sejam nulos,
@@ -296,6 +466,59 @@ ALTER TABLE jogos
 ALTER TABLE duplas
     ADD CONSTRAINT chk_jogador_ordem CHECK (id_jogador_a < id_jogador_b);
 
+-- SEÇÃO 20-A: ATUALIZAÇÃO DE BANCOS CRIADOS POR VERSÕES ANTERIORES DESTE SCRIPT
+-- CREATE TABLE IF NOT EXISTS não altera tabelas que já existem, então as colunas incluídas
+-- depois da criação original são adicionadas aqui, preenchendo as obrigatórias a partir dos
+-- dados existentes. Os comandos podem ser executados novamente e não alteram bancos novos.
+
+-- Grupos pertencem a um torneio: o torneio é obtido das inscrições do grupo ou, na falta
+-- delas, dos seus jogos. Grupos sem inscrições e sem jogos não têm torneio e são removidos.
+ALTER TABLE grupos ADD COLUMN IF NOT EXISTS id_torneio INT REFERENCES torneios(id) ON DELETE CASCADE;
+UPDATE grupos g SET id_torneio = jt.id_torneio
+FROM grupo_jogadores_torneios gjt
+JOIN jogadores_torneios jt ON jt.id = gjt.id_jogador_torneio
+WHERE gjt.id_grupo = g.id AND g.id_torneio IS NULL;
+UPDATE grupos g SET id_torneio = jg.id_torneio
+FROM jogos jg
+WHERE jg.id_grupo = g.id AND g.id_torneio IS NULL;
+DELETE FROM grupos WHERE id_torneio IS NULL;
+ALTER TABLE grupos ALTER COLUMN id_torneio SET NOT NULL;
+
+-- Rodadas pertencem a um torneio e, na fase de grupos, a um grupo; o torneio e o grupo das
+-- rodadas existentes são obtidos dos seus jogos. O número fica nulo nas rodadas antigas.
+ALTER TABLE rodadas ADD COLUMN IF NOT EXISTS id_torneio INT REFERENCES torneios(id) ON DELETE CASCADE;
+ALTER TABLE rodadas ADD COLUMN IF NOT EXISTS id_grupo INT REFERENCES grupos(id) ON DELETE CASCADE;
+ALTER TABLE rodadas ADD COLUMN IF NOT EXISTS numero INT;
+UPDATE rodadas r SET id_torneio = jg.id_torneio, id_grupo = jg.id_grupo
+FROM jogos jg
+WHERE jg.id_rodada = r.id AND r.id_torneio IS NULL;
+
+-- Jogos de chaveamento (eliminatória) não pertencem a um grupo.
+ALTER TABLE jogos ALTER COLUMN id_grupo DROP NOT NULL;
+
+-- Ordem dos critérios de desempate da fase de grupos; torneios existentes usam a ordem padrão.
+ALTER TABLE torneios ADD COLUMN IF NOT EXISTS criterios_desempate TEXT[] NOT NULL DEFAULT '{vitorias,confronto_direto,razao_sets,razao_pontos}';
+
+-- Duração estimada dos jogos usada pelo agendamento; esportes existentes recebem 60 minutos.
+ALTER TABLE esportes ADD COLUMN IF NOT EXISTS duracao_estimada_minutos INT NOT NULL DEFAULT 60;
+
+-- Algoritmo e parâmetros de rating por esporte; esportes existentes passam a usar Elo com K = 32.
+-- Os ratings ficam em ratings_jogadores: a antiga coluna scouts.rating deixa de ser usada.
+ALTER TABLE esportes ADD COLUMN IF NOT EXISTS algoritmo_rating VARCHAR(10) NOT NULL DEFAULT 'elo' CHECK (algoritmo_rating IN ('elo', 'glicko2'));
+ALTER TABLE esportes ADD COLUMN IF NOT EXISTS fator_k_rating INT NOT NULL DEFAULT 32 CHECK (fator_k_rating > 0);
+ALTER TABLE esportes ADD COLUMN IF NOT EXISTS tau_glicko DOUBLE PRECISION NOT NULL DEFAULT 0.5 CHECK (tau_glicko > 0);
+
+-- Dono de torneios e clubes. Os já existentes ficam sem criador e só podem ser alterados por
+-- administradores, que podem adicionar coorganizadores.
+ALTER TABLE torneios ADD COLUMN IF NOT EXISTS id_usuario_criador INT REFERENCES usuarios(id) ON DELETE SET NULL;
+ALTER TABLE clubes ADD COLUMN IF NOT EXISTS id_usuario_criador INT REFERENCES usuarios(id) ON DELETE SET NULL;
+
+-- Dono de circuitos. Os já existentes ficam sem criador e só podem ser alterados por administradores.
+ALTER TABLE circuitos ADD COLUMN IF NOT EXISTS id_usuario_criador INT REFERENCES usuarios(id) ON DELETE SET NULL;
+
+-- Versão dos tokens JWT; usuários existentes começam na versão 1, a mesma gravada nos novos logins.
+ALTER TABLE usuarios ADD COLUMN IF NOT EXISTS versao_token INT NOT NULL DEFAULT 1;
+
 
 -- SEÇÃO 21: ÍNDICES ÚTEIS
 CREATE INDEX IF NOT EXISTS idx_jogadores_nome ON jogadores(nome);
@@ -304,15 +527,25 @@ CREATE INDEX IF NOT EXISTS idx_jogos_data_hora ON jogos(data_hora);
 CREATE INDEX IF NOT EXISTS idx_jogadores_torneios_torneio ON jogadores_torneios(id_torneio);
 CREATE INDEX IF NOT EXISTS idx_jogadores_torneios_categoria ON jogadores_torneios(id_categoria);
 CREATE INDEX IF NOT EXISTS idx_grupos_categoria ON grupos(id_categoria);
+CREATE INDEX IF NOT EXISTS idx_grupos_torneio ON grupos(id_torneio);
+CREATE INDEX IF NOT EXISTS idx_rodadas_grupo ON rodadas(id_grupo);
 CREATE INDEX IF NOT EXISTS idx_jogos_torneio ON jogos(id_torneio);
 CREATE INDEX IF NOT EXISTS idx_jogos_grupo ON jogos(id_grupo);
 CREATE INDEX IF NOT EXISTS idx_jogos_rodada ON jogos(id_rodada);
+CREATE INDEX IF NOT EXISTS idx_chaveamento_partidas_jogo ON chaveamento_partidas(id_jogo);
 CREATE INDEX IF NOT EXISTS idx_jogadores_torneios_jogador ON jogadores_torneios(id_jogador);
 CREATE INDEX IF NOT EXISTS idx_jogadores_torneios_dupla ON jogadores_torneios(id_dupla);
 CREATE INDEX IF NOT EXISTS idx_duplas_jogador_a ON duplas(id_jogador_a);
 CREATE INDEX IF NOT EXISTS idx_duplas_jogador_b ON duplas(id_jogador_b);
 CREATE UNIQUE INDEX IF NOT EXISTS idx_duplas_jogadores_unicos_ordenados ON duplas (id_jogador_a, id_jogador_b);
 CREATE INDEX IF NOT EXISTS idx_placares_jogo ON placares(id_jogo);
+CREATE INDEX IF NOT EXISTS idx_historico_ratings_jogador ON historico_ratings(id_jogador, id_esporte, data_jogo);
+CREATE INDEX IF NOT EXISTS idx_ratings_jogadores_esporte ON ratings_jogadores(id_esporte, tipo_modalidade, rating DESC);
+CREATE INDEX IF NOT EXISTS idx_circuitos_torneios_torneio ON circuitos_torneios(id_torneio);
+CREATE INDEX IF NOT EXISTS idx_eventos_pontuacao_jogo ON eventos_pontuacao(id_jogo, id);
+CREATE INDEX IF NOT EXISTS idx_torneios_organizadores_usuario ON torneios_organizadores(id_usuario);
+CREATE INDEX IF NOT EXISTS idx_clubes_organizadores_usuario ON clubes_organizadores(id_usuario);
+CREATE INDEX IF NOT EXISTS idx_eventos_transmissao_torneio ON eventos_transmissao(id_torneio, transacao, id);
 
 -- SEÇÃO 22: FUNÇÕES E TRIGGERS
 
@@ -321,8 +554,8 @@ CREATE OR REPLACE FUNCTION inserir_scout_para_novo_jogador()
 RETURNS TRIGGER AS $$
 BEGIN
     IF NEW.id_scout IS NULL THEN
-        INSERT INTO scouts (vitorias, derrotas, rating, titulos)
-        VALUES (0, 0, 0, 0)
+        INSERT INTO scouts (vitorias, derrotas, titulos)
+        VALUES (0, 0, 0)
         RETURNING id INTO NEW.id_scout;
     END IF;
     RETURN NEW;
@@ -364,8 +597,8 @@ BEGIN
             -- id_scout será populado pelo trigger trigger_jogador_scout_before_insert
         ) VALUES (
             NEW.id, NEW.nome, NEW.cpf, NEW.data_nascimento, NEW.email, NEW.telefone, NEW.instagram, 
-            'M'::sexo_enum, -- Defina um padrão ou colete essa informação no cadastro do usuário
-            'destro'::tipo_mao_enum, -- Defina um padrão ou colete
+            'M'::sexo_enum, -- Padrão; o cadastro público (POST /auth/signup) grava o sexo informado
+            'destro'::tipo_mao_enum, -- Padrão; o cadastro público grava o tipo de mão informado
             NOW(), NEW.ativo
         );
     END IF;
@@ -413,13 +646,14 @@ FOR EACH ROW
 EXECUTE FUNCTION sincronizar_jogador_com_usuario();
 
 
--- Função auxiliar para atualizar estatísticas de scout
+-- Função auxiliar para atualizar estatísticas de scout.
+-- O rating não é alterado aqui: ele é calculado pela aplicação (Elo ou Glicko-2,
+-- conforme o esporte) quando o resultado do jogo é registrado.
+DROP FUNCTION IF EXISTS _aux_atualizar_estatisticas_scout(INT, BOOLEAN, BOOLEAN, INT, INT, BOOLEAN);
 CREATE OR REPLACE FUNCTION _aux_atualizar_estatisticas_scout(
     p_id_jogador INT,
     p_vitoria BOOLEAN,
-    p_desfazer BOOLEAN, 
-    p_incremento_rating_vitoria INT,
-    p_decremento_rating_derrota INT,
+    p_desfazer BOOLEAN,
     p_eh_final_campeonato BOOLEAN
 )
 RETURNS VOID AS $$
@@ -437,18 +671,11 @@ BEGIN
         IF p_vitoria THEN
             UPDATE scouts
             SET vitorias = vitorias + (1 * v_operador_estatisticas),
-                rating   = rating + (p_incremento_rating_vitoria * v_operador_estatisticas),
                 titulos  = titulos + (CASE WHEN p_eh_final_campeonato THEN 1 ELSE 0 END * v_operador_estatisticas)
             WHERE id = v_id_scout;
         ELSE
             UPDATE scouts
-            SET derrotas = derrotas + (1 * v_operador_estatisticas),
-                -- Para rating em derrota, a lógica de "desfazer" uma subtração é uma adição.
-                -- Se p_desfazer é TRUE, v_operador_estatisticas é -1.
-                -- rating = rating - (val * op) => rating = rating - (val * -1) => rating = rating + val
-                -- Se p_desfazer é FALSE, v_operador_estatisticas é 1.
-                -- rating = rating - (val * op) => rating = rating - (val * 1)  => rating = rating - val
-                rating   = rating - (p_decremento_rating_derrota * v_operador_estatisticas)
+            SET derrotas = derrotas + (1 * v_operador_estatisticas)
             WHERE id = v_id_scout;
         END IF;
     END IF;
@@ -463,9 +690,7 @@ DECLARE
     v_id_jogador_dupla_vencedora_b INT;
     v_id_jogador_dupla_perdedora_a INT;
     v_id_jogador_dupla_perdedora_b INT;
-    v_is_final_campeonato BOOLEAN; 
-    v_rating_vitoria INT := 10; -- Valor de exemplo para incremento de rating em vitória
-    v_rating_derrota INT := 5;  -- Valor de exemplo para decremento de rating em derrota
+    v_is_final_campeonato BOOLEAN;
 BEGIN
     v_is_final_campeonato := COALESCE(NEW.eh_final_campeonato, FALSE);
 
@@ -480,8 +705,6 @@ BEGIN
                 NEW.id_jogador_vencedor,
                 TRUE, -- é vitória
                 FALSE, -- não desfazer
-                v_rating_vitoria,
-                v_rating_derrota,
                 v_is_final_campeonato
             );
 
@@ -490,8 +713,6 @@ BEGIN
                 NEW.id_jogador_perdedor,
                 FALSE, -- não é vitória (é derrota)
                 FALSE, -- não desfazer
-                v_rating_vitoria,
-                v_rating_derrota,
                 FALSE -- Títulos não são concedidos por derrota
             );
 
@@ -507,12 +728,12 @@ BEGIN
             FROM duplas WHERE id = NEW.id_dupla_perdedora;
 
             -- Atualizar scouts dos jogadores da dupla vencedora
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_a, TRUE, FALSE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_b, TRUE, FALSE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_a, TRUE, FALSE, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_b, TRUE, FALSE, v_is_final_campeonato);
 
             -- Atualizar scouts dos jogadores da dupla perdedora
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_a, FALSE, FALSE, v_rating_vitoria, v_rating_derrota, FALSE);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_b, FALSE, FALSE, v_rating_vitoria, v_rating_derrota, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_a, FALSE, FALSE, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_b, FALSE, FALSE, FALSE);
 
         ELSE
             -- Modalidade não especificada ou desconhecida, pode ser útil logar um aviso
@@ -523,44 +744,44 @@ BEGIN
         -- Desfazer estatísticas antigas (usando OLD)
         v_is_final_campeonato := COALESCE(OLD.eh_final_campeonato, FALSE);
         IF OLD.tipo_modalidade = 'simples' THEN
-            PERFORM _aux_atualizar_estatisticas_scout(OLD.id_jogador_vencedor, TRUE, TRUE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
-            PERFORM _aux_atualizar_estatisticas_scout(OLD.id_jogador_perdedor, FALSE, TRUE, v_rating_vitoria, v_rating_derrota, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(OLD.id_jogador_vencedor, TRUE, TRUE, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(OLD.id_jogador_perdedor, FALSE, TRUE, FALSE);
         ELSIF OLD.tipo_modalidade = 'duplas' THEN
             SELECT id_jogador_a, id_jogador_b INTO v_id_jogador_dupla_vencedora_a, v_id_jogador_dupla_vencedora_b FROM duplas WHERE id = OLD.id_dupla_vencedora;
             SELECT id_jogador_a, id_jogador_b INTO v_id_jogador_dupla_perdedora_a, v_id_jogador_dupla_perdedora_b FROM duplas WHERE id = OLD.id_dupla_perdedora;
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_a, TRUE, TRUE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_b, TRUE, TRUE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_a, FALSE, TRUE, v_rating_vitoria, v_rating_derrota, FALSE);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_b, FALSE, TRUE, v_rating_vitoria, v_rating_derrota, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_a, TRUE, TRUE, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_b, TRUE, TRUE, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_a, FALSE, TRUE, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_b, FALSE, TRUE, FALSE);
         END IF;
 
         -- Aplicar novas estatísticas (usando NEW, similar ao INSERT)
         v_is_final_campeonato := COALESCE(NEW.eh_final_campeonato, FALSE);
         IF NEW.tipo_modalidade = 'simples' THEN
-            PERFORM _aux_atualizar_estatisticas_scout(NEW.id_jogador_vencedor, TRUE, FALSE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
-            PERFORM _aux_atualizar_estatisticas_scout(NEW.id_jogador_perdedor, FALSE, FALSE, v_rating_vitoria, v_rating_derrota, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(NEW.id_jogador_vencedor, TRUE, FALSE, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(NEW.id_jogador_perdedor, FALSE, FALSE, FALSE);
         ELSIF NEW.tipo_modalidade = 'duplas' THEN
             SELECT id_jogador_a, id_jogador_b INTO v_id_jogador_dupla_vencedora_a, v_id_jogador_dupla_vencedora_b FROM duplas WHERE id = NEW.id_dupla_vencedora;
             SELECT id_jogador_a, id_jogador_b INTO v_id_jogador_dupla_perdedora_a, v_id_jogador_dupla_perdedora_b FROM duplas WHERE id = NEW.id_dupla_perdedora;
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_a, TRUE, FALSE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_b, TRUE, FALSE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_a, FALSE, FALSE, v_rating_vitoria, v_rating_derrota, FALSE);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_b, FALSE, FALSE, v_rating_vitoria, v_rating_derrota, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_a, TRUE, FALSE, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_b, TRUE, FALSE, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_a, FALSE, FALSE, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_b, FALSE, FALSE, FALSE);
         END IF;
 
     ELSIF TG_OP = 'DELETE' THEN
         -- Desfazer estatísticas do jogo excluído (usando OLD)
         v_is_final_campeonato := COALESCE(OLD.eh_final_campeonato, FALSE);
         IF OLD.tipo_modalidade = 'simples' THEN
-            PERFORM _aux_atualizar_estatisticas_scout(OLD.id_jogador_vencedor, TRUE, TRUE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
-            PERFORM _aux_atualizar_estatisticas_scout(OLD.id_jogador_perdedor, FALSE, TRUE, v_rating_vitoria, v_rating_derrota, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(OLD.id_jogador_vencedor, TRUE, TRUE, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(OLD.id_jogador_perdedor, FALSE, TRUE, FALSE);
         ELSIF OLD.tipo_modalidade = 'duplas' THEN
             SELECT id_jogador_a, id_jogador_b INTO v_id_jogador_dupla_vencedora_a, v_id_jogador_dupla_vencedora_b FROM duplas WHERE id = OLD.id_dupla_vencedora;
             SELECT id_jogador_a, id_jogador_b INTO v_id_jogador_dupla_perdedora_a, v_id_jogador_dupla_perdedora_b FROM duplas WHERE id = OLD.id_dupla_perdedora;
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_a, TRUE, TRUE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_b, TRUE, TRUE, v_rating_vitoria, v_rating_derrota, v_is_final_campeonato);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_a, FALSE, TRUE, v_rating_vitoria, v_rating_derrota, FALSE);
-            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_b, FALSE, TRUE, v_rating_vitoria, v_rating_derrota, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_a, TRUE, TRUE, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_vencedora_b, TRUE, TRUE, v_is_final_campeonato);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_a, FALSE, TRUE, FALSE);
+            PERFORM _aux_atualizar_estatisticas_scout(v_id_jogador_dupla_perdedora_b, FALSE, TRUE, FALSE);
         ELSE
             RAISE WARNING 'Tipo de modalidade não especificado ou desconhecido para o jogo ID: % ao tentar deletar.', OLD.id;
         END IF;
@@ -579,17 +800,42 @@ AFTER INSERT OR UPDATE OR DELETE ON jogos -- Adicionado UPDATE e DELETE para con
 FOR EACH ROW
 EXECUTE FUNCTION atualizar_estatisticas_scout_jogo_completo();
 
+-- Trigger para transmitir as mudanças de situação (aguardando -> em andamento -> encerrado) e de
+-- quadra/horário dos jogos. Os eventos de placar são gravados pela aplicação, que calcula o placar.
+CREATE OR REPLACE FUNCTION transmitir_alteracao_jogo()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.situacao IS DISTINCT FROM OLD.situacao THEN
+        INSERT INTO eventos_transmissao (id_torneio, id_jogo, tipo, quadra, dados)
+        VALUES (NEW.id_torneio, NEW.id, 'situacao', NEW.localizacao, jsonb_build_object(
+            'id_jogo', NEW.id, 'situacao_anterior', OLD.situacao, 'situacao', NEW.situacao));
+    END IF;
+    IF NEW.localizacao IS DISTINCT FROM OLD.localizacao OR NEW.data_hora IS DISTINCT FROM OLD.data_hora THEN
+        INSERT INTO eventos_transmissao (id_torneio, id_jogo, tipo, quadra, quadra_anterior, dados)
+        VALUES (NEW.id_torneio, NEW.id, 'quadra', NEW.localizacao, OLD.localizacao, jsonb_build_object(
+            'id_jogo', NEW.id, 'quadra', NEW.localizacao, 'quadra_anterior', OLD.localizacao, 'data_hora', NEW.data_hora));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_transmitir_alteracao_jogo ON jogos;
+CREATE TRIGGER trigger_transmitir_alteracao_jogo
+AFTER UPDATE OF situacao, localizacao, data_hora ON jogos
+FOR EACH ROW
+EXECUTE FUNCTION transmitir_alteracao_jogo();
+
 -- SEÇÃO 23: INSERÇÃO DE DADOS INICIAIS (SEEDING)
 -- Esta seção é para popular tabelas com dados essenciais que são necessários para o funcionamento da aplicação.
 
 -- Inserir os esportes definidos no ENUM na tabela de esportes.
 -- O uso de 'ON CONFLICT (nome) DO NOTHING' garante que a execução repetida deste script não causará erros de duplicidade.
-INSERT INTO esportes (nome) VALUES
-('Badminton'),
-('Beach Tenis'),
-('Padel'),
-('Pickleball'),
-('Squash'),
-('Tenis'),
-('Tenis de Mesa')
+INSERT INTO esportes (nome, duracao_estimada_minutos) VALUES
+('Badminton', 45),
+('Beach Tenis', 60),
+('Padel', 90),
+('Pickleball', 45),
+('Squash', 45),
+('Tenis', 90),
+('Tenis de Mesa', 30)
 ON CONFLICT (nome) DO NOTHING;
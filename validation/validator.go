@@ -61,11 +61,12 @@ func TranslateError(err error) map[string]string {
 	return errorMessages
 }
 
-// validateUserType é uma função de validação customizada para o tipo de usuário.
+// validateUserType é uma função de validação customizada para o tipo de usuário,
+// aceitando os valores do enum tipo_usuario.
 func validateUserType(fl validator.FieldLevel) bool {
 	userType := fl.Field().String()
 	switch userType {
-	case "organizador", "jogador":
+	case "jogador", "usuario", "admin", "gestor_clube", "gestor_torneio":
 		return true
 	}
 	return false